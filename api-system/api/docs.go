@@ -49,6 +49,63 @@ var doc = `{
                 }
             }
         },
+        "/v1/cards": {
+            "get": {
+                "tags": [
+                    "Card API"
+                ],
+                "summary": "分页获取卡牌图鉴",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page num, start from 1",
+                        "name": "page_num",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, max 1000",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/cards/detail": {
+            "get": {
+                "tags": [
+                    "Card API"
+                ],
+                "summary": "按名称获取卡牌图鉴中的卡牌",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "card name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/cqhttp/receive/event": {
             "post": {
                 "security": [
@@ -89,7 +146,149 @@ var doc = `{
                 "tags": [
                     "CQHttp API"
                 ],
-                "summary": "获取cqhttp的最新状态",
+                "summary": "获取所有cqhttp账号的最新状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/ban-events": {
+            "get": {
+                "tags": [
+                    "Group API"
+                ],
+                "summary": "分页获取群内关注的玩家的封禁状态变化记录，按时间倒序",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page num, start from 1",
+                        "name": "page_num",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, max 1000",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/ban-watches": {
+            "get": {
+                "tags": [
+                    "Group API"
+                ],
+                "summary": "获取群内关注的玩家以及最近一次检查的封禁状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/leaderboard": {
+            "get": {
+                "tags": [
+                    "Group API"
+                ],
+                "summary": "分页获取群内绑定了游戏昵称的成员排行",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "win_rate, kd, mission or ts_rate, default win_rate",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ab, rb or sb, default rb",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page num, start from 1",
+                        "name": "page_num",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, max 1000",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/kook/receive/event": {
+            "post": {
+                "tags": [
+                    "Kook API"
+                ],
+                "summary": "接收kook的webhook事件",
+                "parameters": [
+                    {
+                        "description": "kook webhook event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -166,6 +365,69 @@ var doc = `{
                 }
             }
         },
+        "/v1/wt/profile/compare": {
+            "get": {
+                "tags": [
+                    "GameUser API"
+                ],
+                "summary": "对比两个游戏内玩家的数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user nickname a",
+                        "name": "nick_a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user nickname b",
+                        "name": "nick_b",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wt/profile/history": {
+            "get": {
+                "tags": [
+                    "GameUser API"
+                ],
+                "summary": "获取游戏内玩家数据在最近一段时间内的变化",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user nickname",
+                        "name": "nick",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "days, default 7",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.ApiJson"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/wt/profile/update": {
             "post": {
                 "tags": [
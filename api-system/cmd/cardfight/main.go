@@ -1,21 +1,41 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
+	"github.com/axiangcoding/antonstar-bot/static"
 )
 
 // TODO 卡牌对战程序
 func main() {
-	card99A := cardfight.InitCarItem("ZTZ99A", "用户一号", 10, 9, 7, 6)
-	cardL2A6 := cardfight.InitCarItem("豹2A6", "用户二号", 10, 7, 8, 6)
+	seed := flag.Int64("seed", 0, "random seed, use the same seed to replay a fight")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = cardfight.NewSeed()
+	}
+	fmt.Printf("seed: %d\n\n", *seed)
 
-	match := cardfight.FightMatch{
-		A: *card99A,
-		B: *cardL2A6,
+	catalogue, err := cardfight.ParseCatalogue(static.MustReadCardFileAsBytes("catalogue.json"))
+	if err != nil {
+		fmt.Printf("parse card catalogue failed: %v\n", err)
+		return
+	}
+	fight := func(nameA string, userA string, nameB string, userB string) {
+		cardA, okA := catalogue.Find(nameA)
+		cardB, okB := catalogue.Find(nameB)
+		if !okA || !okB {
+			fmt.Printf("card %s or %s not found in catalogue\n", nameA, nameB)
+			return
+		}
+		match := cardfight.FightMatch{
+			A:    cardA.NewItem(userA, 10),
+			B:    cardB.NewItem(userB, 10),
+			Seed: *seed,
+		}
+		fmt.Println(cardfight.GenerateFightText(match.Fight()))
 	}
-	result := match.Fight()
 
-	txt := cardfight.GenerateFightText(result)
-	fmt.Println(txt)
+	fight("ZTZ99A", "用户一号", "豹2A6", "用户二号")
+	fight("A-10A", "用户三号", "猎豹防空炮", "用户四号")
 }
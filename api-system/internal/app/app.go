@@ -7,8 +7,15 @@ import (
 	"github.com/axiangcoding/antonstar-bot/internal/controller/http/v1"
 	"github.com/axiangcoding/antonstar-bot/internal/cron"
 	"github.com/axiangcoding/antonstar-bot/internal/data"
+	"github.com/axiangcoding/antonstar-bot/internal/service"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
+	"github.com/axiangcoding/antonstar-bot/pkg/cqhttp"
+	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
+	"github.com/axiangcoding/antonstar-bot/pkg/kook"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"github.com/axiangcoding/antonstar-bot/setting"
+	"github.com/axiangcoding/antonstar-bot/static"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"os"
@@ -23,9 +30,40 @@ func initProject() {
 	logging.InitLogger(cfg.App.Log.Level, cfg.App.Log.File.Dir, cfg.App.Log.File.Encoder, cfg.Server.RunMode)
 	data.InitData(cfg.App.Data.Db.Source, cfg.App.Data.Db.MaxOpenConn, cfg.App.Data.Db.MaxIdleConn)
 	cache.InitRedis(cfg.App.Data.Cache.Source)
+	initBotAdapter()
+	initCrawler()
+	initCardCatalogue()
+	service.StartMissionWorkers(cfg.App.Mission.Workers, cfg.App.Mission.MaxAttempts)
 	cron.InitCronJob()
 }
 
+func initBotAdapter() {
+	bot.RegisterAdapter(cqhttp.Adapter{})
+	kookConf := setting.C().App.Service.Kook
+	if kookConf.Enable {
+		bot.RegisterAdapter(kook.NewAdapter(kook.NewClient(kookConf.BaseUrl, kookConf.Token)))
+	}
+}
+
+func initCardCatalogue() {
+	if err := cardfight.LoadDefaultCatalogue(static.MustReadCardFileAsBytes("catalogue.json")); err != nil {
+		logging.L().Fatal("load card catalogue failed", logging.Error(err))
+	}
+}
+
+func initCrawler() {
+	crawlerConf := setting.C().App.Crawler
+	if err := crawler.Setup(crawler.Options{
+		Proxies:     crawlerConf.Proxies,
+		CookieFile:  crawlerConf.CookieFile,
+		MinInterval: crawlerConf.MinInterval,
+		BaseBackoff: crawlerConf.BaseBackoff,
+		MaxBackoff:  crawlerConf.MaxBackoff,
+	}); err != nil {
+		logging.L().Fatal("init crawler failed", logging.Error(err))
+	}
+}
+
 func Run() {
 	initProject()
 	runMode := setting.C().Server.RunMode
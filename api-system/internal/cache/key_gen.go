@@ -8,6 +8,11 @@ const (
 	BiliRoomLivingPrefix  = "BiliRoom"
 	GroupUsageLimitPrefix = "GroupUsageLimit"
 	UserUsageLimitPrefix  = "UserUsageLimit"
+	CardDrawPrefix        = "CardDraw"
+	MissionPrefix         = "Mission"
+	GameClanPrefix        = "GameClan"
+	GroupMemberPrefix     = "GroupMember"
+	BindingVerifyPrefix   = "BindingVerify"
 )
 
 func GenerateCQHTTPCacheKey(postType string, eventType string, selfId int64) string {
@@ -18,6 +23,21 @@ func GenerateGameUserCacheKey(nickname string) string {
 	return fmt.Sprintf("%s:%s", GameUserPrefix, nickname)
 }
 
+// GenerateGameClanCacheKey 联队资料的刷新标记，存在时不再重新爬取
+func GenerateGameClanCacheKey(tag string) string {
+	return fmt.Sprintf("%s:%s", GameClanPrefix, tag)
+}
+
+// GenerateGroupMemberCacheKey 群成员的记录标记，存在时不再重复写入数据库
+func GenerateGroupMemberCacheKey(groupId, userId int64) string {
+	return fmt.Sprintf("%s:%d;%d", GroupMemberPrefix, groupId, userId)
+}
+
+// GenerateBindingVerifyCacheKey 用户正在进行的绑定验证，值为待绑定的昵称和开始验证时的称号
+func GenerateBindingVerifyCacheKey(userId int64) string {
+	return fmt.Sprintf("%s:%d", BindingVerifyPrefix, userId)
+}
+
 func GenerateBiliRoomLivingCacheKey(groupId, roomId int64) string {
 	return fmt.Sprintf("%s:%d;%d", BiliRoomLivingPrefix, groupId, roomId)
 }
@@ -29,3 +49,17 @@ func GenerateGroupUsageLimitCacheKey(groupId int64) string {
 func GenerateUserUsageLimitCacheKey(userId int64) string {
 	return fmt.Sprintf("%s:%d", UserUsageLimitPrefix, userId)
 }
+
+func GenerateCardDrawCacheKey(userId int64, date string) string {
+	return fmt.Sprintf("%s:%d;%s", CardDrawPrefix, userId, date)
+}
+
+// GenerateMissionFinishedChannel 任务结束时发布通知的频道，消息内容为任务id
+func GenerateMissionFinishedChannel() string {
+	return fmt.Sprintf("%s:finished", MissionPrefix)
+}
+
+// GenerateMissionInflightCacheKey 正在执行的任务，值为任务id，用于合并相同的任务
+func GenerateMissionInflightCacheKey(missionType string, dedupKey string) string {
+	return fmt.Sprintf("%s:inflight;%s;%s", MissionPrefix, missionType, dedupKey)
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/entity/app"
+	"github.com/axiangcoding/antonstar-bot/internal/entity/e"
+	"github.com/axiangcoding/antonstar-bot/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type CardCatalogueResp struct {
+	Total int                     `json:"total"`
+	Cards []display.CatalogueCard `json:"cards"`
+}
+
+// CardCatalogue
+// @Summary  分页获取卡牌图鉴
+// @Tags     Card API
+// @Param    page_num   query     int          true  "page num, start from 1"
+// @Param    page_size  query     int          true  "page size, max 1000"
+// @Success  200        {object}  app.ApiJson  ""
+// @Router   /v1/cards [get]
+func CardCatalogue(c *gin.Context) {
+	var pagination app.Pagination
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	cards, total := service.FindCatalogueCards(pagination.ToOffsetLimit())
+	app.Success(c, CardCatalogueResp{
+		Total: total,
+		Cards: cards,
+	})
+}
+
+type CardDetailResp struct {
+	Found bool                   `json:"found"`
+	Card  *display.CatalogueCard `json:"card,omitempty"`
+}
+
+// CardDetail
+// @Summary  按名称获取卡牌图鉴中的卡牌
+// @Tags     Card API
+// @Param    name  query     string       true  "card name"
+// @Success  200   {object}  app.ApiJson  ""
+// @Router   /v1/cards/detail [get]
+func CardDetail(c *gin.Context) {
+	card, found := service.FindCatalogueCard(c.Query("name"))
+	app.Success(c, CardDetailResp{
+		Found: found,
+		Card:  card,
+	})
+}
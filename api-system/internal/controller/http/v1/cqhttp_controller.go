@@ -4,10 +4,14 @@ import (
 	"github.com/axiangcoding/antonstar-bot/internal/entity/app"
 	"github.com/axiangcoding/antonstar-bot/internal/entity/e"
 	"github.com/axiangcoding/antonstar-bot/internal/service"
+	"github.com/axiangcoding/antonstar-bot/pkg/cqhttp"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"github.com/axiangcoding/antonstar-bot/setting"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/panjf2000/ants/v2"
+	"net/http"
+	"strconv"
 )
 
 // CqHttpReceiveEvent
@@ -37,23 +41,62 @@ func CqHttpReceiveEvent(c *gin.Context) {
 }
 
 // CqHttpStatus
-// @Summary  获取cqhttp的最新状态
+// @Summary  获取所有cqhttp账号的最新状态
 // @Tags     CQHttp API
 // @Success  200  {object}  app.ApiJson  ""
 // @Router   /v1/cqhttp/status [get]
 func CqHttpStatus(c *gin.Context) {
-	defaultSelfId := setting.C().App.Service.CqHttp.SelfQQ
-	status, err := service.GetCqHttpStatus(c, defaultSelfId)
-	logging.L().Info("", logging.Any("status", status))
-	mp := map[string]any{
-		"app_enabled": status.Status.AppEnabled,
-		"app_good":    status.Status.AppGood,
-		"online":      status.Status.Online,
-		"plugin_good": status.Status.PluginsGood,
+	var list []map[string]any
+	for _, account := range setting.C().App.Service.CqHttp {
+		mp := map[string]any{
+			"self_id": account.SelfQQ,
+		}
+		status, err := service.GetCqHttpStatus(c, account.SelfQQ)
+		if err != nil {
+			// 一段时间内没有收到心跳时缓存会过期，视为离线
+			logging.L().Warn("get cqhttp status failed",
+				logging.Any("self_id", account.SelfQQ),
+				logging.Error(err))
+			mp["online"] = false
+		} else {
+			mp["app_enabled"] = status.Status.AppEnabled
+			mp["app_good"] = status.Status.AppGood
+			mp["online"] = status.Status.Online
+			mp["plugin_good"] = status.Status.PluginsGood
+		}
+		list = append(list, mp)
 	}
+	app.Success(c, list)
+}
+
+var wsUpgrader = websocket.Upgrader{
+	// cqhttp 不是浏览器，不会携带 Origin 请求头
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// CqHttpReverseWs cqhttp的反向websocket连接，同时承载事件上报和api调用
+func CqHttpReverseWs(c *gin.Context) {
+	selfId, _ := strconv.ParseInt(c.GetHeader("X-Self-ID"), 10, 64)
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		app.BizFailed(c, e.Error, err)
+		logging.L().Warn("upgrade websocket failed", logging.Error(err))
 		return
 	}
-	app.Success(c, mp)
+	wsConn := cqhttp.NewWsConn(selfId, conn)
+	cqhttp.RegisterWsConn(wsConn)
+	defer cqhttp.UnregisterWsConn(wsConn)
+	logging.L().Info("cqhttp reverse websocket connected", logging.Any("self_id", selfId))
+
+	cp := c.Copy()
+	err = wsConn.Serve(func(data map[string]any) {
+		if err := ants.Submit(func() {
+			if err := service.HandleCqHttpEvent(cp, data); err != nil {
+				logging.L().Error("async handle cqhttp event failed", logging.Error(err))
+			}
+		}); err != nil {
+			logging.L().Error("ant submit error.", logging.Error(err))
+		}
+	})
+	logging.L().Info("cqhttp reverse websocket disconnected",
+		logging.Any("self_id", selfId), logging.Error(err))
 }
@@ -3,21 +3,21 @@ package v1
 import (
 	"errors"
 	"github.com/axiangcoding/antonstar-bot/internal/data/display"
-	"github.com/axiangcoding/antonstar-bot/internal/data/table"
 	"github.com/axiangcoding/antonstar-bot/internal/entity/app"
 	"github.com/axiangcoding/antonstar-bot/internal/entity/e"
 	"github.com/axiangcoding/antonstar-bot/internal/service"
-	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"github.com/panjf2000/ants/v2"
 	"gorm.io/gorm"
+	"strconv"
 )
 
 type ProfileResp struct {
 	Found   bool              `json:"found"`
 	Profile *display.GameUser `json:"profile,omitempty"`
+	// 每个数据来源最近一次的获取情况
+	Sources []display.GameUserSource `json:"sources,omitempty"`
 }
 
 // GameUserProfile
@@ -40,9 +40,21 @@ func GameUserProfile(c *gin.Context) {
 		return
 	}
 	displayGameUser := profile.ToDisplayGameUser()
+	if displayGameUser.BindingState, err = service.FindBindingState(nick); err != nil {
+		logging.L().Warn("find binding state failed", logging.Error(err))
+	}
+	sources, err := service.FindGameUserSources(nick)
+	if err != nil {
+		logging.L().Warn("find game user sources failed", logging.Error(err))
+	}
+	var displaySources []display.GameUserSource
+	for _, source := range sources {
+		displaySources = append(displaySources, source.ToDisplay())
+	}
 	app.Success(c, ProfileResp{
 		Found:   true,
 		Profile: &displayGameUser,
+		Sources: displaySources,
 	})
 }
 
@@ -70,71 +82,106 @@ func UpdateGameUserProfile(c *gin.Context) {
 		return
 	}
 
-	missionId := uuid.NewString()
-	form := service.ScheduleForm{
-		Nick: nickname,
-	}
-	if err := service.SubmitMissionWithDetail(missionId, table.MissionTypeUserInfo, form); err != nil {
+	missionId, err := service.RefreshWTUserInfo(nickname, bot.Reply{})
+	if err != nil {
 		app.BizFailed(c, e.Error, err)
 		return
 	}
-	if err := ants.Submit(func() {
-		if err := crawler.GetProfileFromWTOfficial(nickname,
-			func(status int, user *table.GameUser) {
-				switch status {
-				case crawler.StatusQueryFailed:
-					service.MustFinishMissionWithResult(missionId, table.MissionStatusFailed, service.CrawlerResult{
-						Found: false,
-						Nick:  nickname,
-					})
-				case crawler.StatusNotFound:
-					service.MustPutRefreshFlag(nickname)
-					service.MustFinishMissionWithResult(missionId, table.MissionStatusSuccess, service.CrawlerResult{
-						Found: false,
-						Nick:  nickname,
-					})
-				case crawler.StatusFound:
-					// live, psn等用户的昵称在html中会被cf认为是邮箱而隐藏，这里需要覆盖爬取来的数据
-					user.Nick = nickname
-					_, err := service.FindGameProfile(nickname)
-					if err != nil {
-						if errors.Is(err, gorm.ErrRecordNotFound) {
-							service.MustSaveGameProfile(user)
-						} else {
-							logging.L().Warn("find game profile failed", logging.Error(err))
-						}
-					} else {
-						service.MustUpdateGameProfile(nickname, user)
-					}
+	app.Success(c, map[string]any{
+		"refresh":   true,
+		"missionId": *missionId,
+	})
+}
+
+type ProfileHistoryResp struct {
+	Found bool                  `json:"found"`
+	Trend *display.ProfileTrend `json:"trend,omitempty"`
+}
 
-					if err := crawler.GetProfileFromThunderskill(nickname, func(status int, skill *crawler.ThunderSkillResp) {
-						skillData := skill.Stats
-						data, err := service.FindGameProfile(nickname)
-						data.TsSBRate = skillData.S.Kpd
-						data.TsRBRate = skillData.R.Kpd
-						data.TsABRate = skillData.A.Kpd
-						if err == nil {
-							service.MustUpdateGameProfile(nickname, data)
-						}
-					}); err != nil {
-						logging.L().Warn("failed on update thunder skill profile. ", logging.Error(err))
-					}
-					service.MustPutRefreshFlag(nickname)
-					service.MustFinishMissionWithResult(missionId, table.MissionStatusSuccess, service.CrawlerResult{
-						Found: true,
-						Nick:  nickname,
-						Data:  *user},
-					)
-				}
-			}); err != nil {
-			logging.L().Warn("start crawler failed. ", logging.Error(err))
+// GameUserProfileHistory
+// @Summary  获取游戏内玩家数据在最近一段时间内的变化
+// @Tags     GameUser API
+// @Param    nick  query     string       true   "user nickname"
+// @Param    days  query     int          false  "days, default 7"
+// @Success  200   {object}  app.ApiJson  ""
+// @Router   /v1/wt/profile/history [get]
+func GameUserProfileHistory(c *gin.Context) {
+	nick := c.Query("nick")
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	if days <= 0 {
+		app.BadRequest(c, e.RequestParamsNotValid)
+		return
+	}
+	trend, err := service.FindProfileTrend(nick, days)
+	if err != nil {
+		if errors.Is(err, service.ErrNotEnoughSnapshot) {
+			app.Success(c, ProfileHistoryResp{
+				Found: false,
+			})
+			return
 		}
-	}); err != nil {
 		app.BizFailed(c, e.Error, err)
 		return
 	}
-	app.Success(c, map[string]any{
-		"refresh":   true,
-		"missionId": missionId,
+	app.Success(c, ProfileHistoryResp{
+		Found: true,
+		Trend: trend,
+	})
+}
+
+type ProfileCompareResp struct {
+	Found   bool                    `json:"found"`
+	Compare *display.ProfileCompare `json:"compare,omitempty"`
+	// 没有数据的玩家昵称，会自动提交爬取任务
+	Missing    []string `json:"missing,omitempty"`
+	MissionIds []string `json:"mission_ids,omitempty"`
+}
+
+// GameUserProfileCompare
+// @Summary  对比两个游戏内玩家的数据
+// @Tags     GameUser API
+// @Param    nick_a  query     string       true  "user nickname a"
+// @Param    nick_b  query     string       true  "user nickname b"
+// @Success  200     {object}  app.ApiJson  ""
+// @Router   /v1/wt/profile/compare [get]
+func GameUserProfileCompare(c *gin.Context) {
+	nickA := c.Query("nick_a")
+	nickB := c.Query("nick_b")
+	if !service.IsValidNickname(nickA) || !service.IsValidNickname(nickB) {
+		app.BadRequest(c, e.RequestParamsNotValid)
+		return
+	}
+	compare, missing, err := service.CompareGameProfiles(nickA, nickB)
+	if err != nil {
+		app.BizFailed(c, e.Error, err)
+		return
+	}
+	if len(missing) == 0 {
+		app.Success(c, ProfileCompareResp{
+			Found:   true,
+			Compare: compare,
+		})
+		return
+	}
+	var missionIds []string
+	for _, nick := range missing {
+		if !service.CanBeRefresh(nick) {
+			continue
+		}
+		missionId, err := service.RefreshWTUserInfo(nick, bot.Reply{})
+		if err != nil {
+			logging.L().Warn("refresh WT gamer profile error", logging.Error(err))
+			continue
+		}
+		missionIds = append(missionIds, *missionId)
+	}
+	app.Success(c, ProfileCompareResp{
+		Found:      false,
+		Missing:    missing,
+		MissionIds: missionIds,
 	})
 }
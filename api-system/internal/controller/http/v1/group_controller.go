@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/internal/entity/app"
+	"github.com/axiangcoding/antonstar-bot/internal/entity/e"
+	"github.com/axiangcoding/antonstar-bot/internal/service"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+// GroupLeaderboard
+// @Summary  分页获取群内绑定了游戏昵称的成员排行
+// @Tags     Group API
+// @Param    id         path      int          true   "group id"
+// @Param    metric     query     string       false  "win_rate, kd, mission or ts_rate, default win_rate"
+// @Param    mode       query     string       false  "ab, rb or sb, default rb"
+// @Param    page_num   query     int          true   "page num, start from 1"
+// @Param    page_size  query     int          true   "page size, max 1000"
+// @Success  200        {object}  app.ApiJson  ""
+// @Router   /v1/groups/{id}/leaderboard [get]
+func GroupLeaderboard(c *gin.Context) {
+	groupId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	var pagination app.Pagination
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	metric, ok := table.ParseLeaderboardMetric(c.DefaultQuery("metric", table.LeaderboardMetricWinRate))
+	if !ok {
+		app.BadRequest(c, e.RequestParamsNotValid)
+		return
+	}
+	mode, ok := table.ParseGameMode(c.DefaultQuery("mode", table.GameModeRb))
+	if !ok {
+		app.BadRequest(c, e.RequestParamsNotValid)
+		return
+	}
+	offset, limit := pagination.ToOffsetLimit()
+	leaderboard, err := service.FindGroupLeaderboard(groupId, metric, mode, offset, limit)
+	if err != nil {
+		app.BizFailed(c, e.Error, err)
+		return
+	}
+	app.Success(c, leaderboard)
+}
+
+// GroupBanWatches
+// @Summary  获取群内关注的玩家以及最近一次检查的封禁状态
+// @Tags     Group API
+// @Param    id   path      int          true  "group id"
+// @Success  200  {object}  app.ApiJson  ""
+// @Router   /v1/groups/{id}/ban-watches [get]
+func GroupBanWatches(c *gin.Context) {
+	groupId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	list, err := service.FindBanWatchList(groupId)
+	if err != nil {
+		app.BizFailed(c, e.Error, err)
+		return
+	}
+	app.Success(c, list)
+}
+
+type GroupBanEventsResp struct {
+	Total  int64              `json:"total"`
+	Events []display.BanEvent `json:"events"`
+}
+
+// GroupBanEvents
+// @Summary  分页获取群内关注的玩家的封禁状态变化记录，按时间倒序
+// @Tags     Group API
+// @Param    id         path      int          true  "group id"
+// @Param    page_num   query     int          true  "page num, start from 1"
+// @Param    page_size  query     int          true  "page size, max 1000"
+// @Success  200        {object}  app.ApiJson  ""
+// @Router   /v1/groups/{id}/ban-events [get]
+func GroupBanEvents(c *gin.Context) {
+	groupId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	var pagination app.Pagination
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	offset, limit := pagination.ToOffsetLimit()
+	events, total, err := service.FindBanEvents(groupId, offset, limit)
+	if err != nil {
+		app.BizFailed(c, e.Error, err)
+		return
+	}
+	app.Success(c, GroupBanEventsResp{
+		Total:  total,
+		Events: events,
+	})
+}
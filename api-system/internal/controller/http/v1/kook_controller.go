@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"github.com/axiangcoding/antonstar-bot/internal/entity/app"
+	"github.com/axiangcoding/antonstar-bot/internal/entity/e"
+	"github.com/axiangcoding/antonstar-bot/internal/service"
+	"github.com/axiangcoding/antonstar-bot/pkg/kook"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/axiangcoding/antonstar-bot/setting"
+	"github.com/gin-gonic/gin"
+	"github.com/panjf2000/ants/v2"
+	"io"
+	"net/http"
+)
+
+// KookReceiveEvent
+// @Summary  接收kook的webhook事件
+// @Tags     Kook API
+// @Param    event  body      object       true  "kook webhook event"
+// @Success  200    {object}  app.ApiJson  ""
+// @Router   /v1/kook/receive/event [post]
+func KookReceiveEvent(c *gin.Context) {
+	conf := setting.C().App.Service.Kook
+	// 未启用或未配置verify_token时拒绝所有请求，避免接受伪造的事件
+	if !conf.Enable || conf.VerifyToken == "" {
+		app.Forbidden(c, e.NoPermission)
+		return
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	payload, err := kook.DecodeWebhookBody(body, conf.EncryptKey)
+	if err != nil {
+		app.BadRequest(c, e.RequestParamsNotValid, err)
+		return
+	}
+	// 处理事件内容之前先校验verify_token
+	if subtle.ConstantTimeCompare([]byte(payload.D.VerifyToken), []byte(conf.VerifyToken)) != 1 {
+		app.Unauthorized(c, e.TokenNotValid)
+		return
+	}
+	// webhook地址验证需要原样返回challenge
+	if payload.D.IsChallenge() {
+		c.JSON(http.StatusOK, gin.H{"challenge": payload.D.Challenge})
+		return
+	}
+	if err := ants.Submit(func() {
+		if err := service.HandleKookEvent(payload); err != nil {
+			logging.L().Error("async handle kook event failed", logging.Error(err))
+		}
+	}); err != nil {
+		logging.L().Error("ant submit error.", logging.Error(err))
+	}
+	app.Success(c, nil)
+}
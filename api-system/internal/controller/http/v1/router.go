@@ -49,6 +49,7 @@ func InitRouter() *gin.Engine {
 	base := r.Group(setting.C().Server.BasePath)
 	setWebResources(base)
 	setRouterApiV1(base)
+	setRouterWs(base)
 	return r
 }
 
@@ -72,16 +73,35 @@ func setRouterApiV1(r *gin.RouterGroup) {
 		}
 		cqhttp := groupV1.Group("/cqhttp")
 		{
-			cqhttpAuth := middleware.CqhttpAuth(
-				setting.C().App.Service.CqHttp.SelfQQ,
-				setting.C().App.Service.CqHttp.Secret)
+			secrets := make(map[int64]string)
+			for _, account := range setting.C().App.Service.CqHttp {
+				secrets[account.SelfQQ] = account.Secret
+			}
+			cqhttpAuth := middleware.CqhttpAuth(secrets)
 			cqhttp.POST("/receive/event", cqhttpAuth, CqHttpReceiveEvent)
 			cqhttp.GET("/status", CqHttpStatus)
 		}
+		kook := groupV1.Group("/kook")
+		{
+			kook.POST("/receive/event", KookReceiveEvent)
+		}
 		wt := groupV1.Group("/wt")
 		{
 			wt.GET("/profile", GameUserProfile)
 			wt.POST("/profile/update", UpdateGameUserProfile)
+			wt.GET("/profile/history", GameUserProfileHistory)
+			wt.GET("/profile/compare", GameUserProfileCompare)
+		}
+		cards := groupV1.Group("/cards")
+		{
+			cards.GET("", CardCatalogue)
+			cards.GET("/detail", CardDetail)
+		}
+		groups := groupV1.Group("/groups")
+		{
+			groups.GET("/:id/ban-events", GroupBanEvents)
+			groups.GET("/:id/ban-watches", GroupBanWatches)
+			groups.GET("/:id/leaderboard", GroupLeaderboard)
 		}
 		mission := groupV1.Group("/mission")
 		{
@@ -90,6 +110,21 @@ func setRouterApiV1(r *gin.RouterGroup) {
 	}
 }
 
+// setRouterWs onebot v11 反向websocket的地址固定为 /ws/
+func setRouterWs(r *gin.RouterGroup) {
+	accessTokens := make(map[int64]string)
+	for _, account := range setting.C().App.Service.CqHttp {
+		if account.AccessToken == "" {
+			logging.L().Warn("access token of cqhttp account is empty, reverse websocket of the account is disabled",
+				logging.Any("self_qq", account.SelfQQ))
+		}
+		accessTokens[account.SelfQQ] = account.AccessToken
+	}
+	wsAuth := middleware.CqhttpWsAuth(accessTokens)
+	r.GET("/ws", wsAuth, CqHttpReverseWs)
+	r.GET("/ws/", wsAuth, CqHttpReverseWs)
+}
+
 func setSwagger(r *gin.RouterGroup) {
 	if setting.C().App.Swagger.Enable {
 		swagger.SwaggerInfo.Version = setting.C().App.Version
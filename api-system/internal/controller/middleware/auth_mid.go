@@ -4,16 +4,19 @@ import (
 	"bytes"
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/subtle"
 	"encoding/hex"
 	"github.com/axiangcoding/antonstar-bot/internal/entity/app"
 	"github.com/axiangcoding/antonstar-bot/internal/entity/e"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"github.com/gin-gonic/gin"
 	"io"
 	"strconv"
+	"strings"
 )
 
-// CqhttpAuth 判断X-Self-ID是否和配置项相同，同时当X-Signature存在时，校验签名
-func CqhttpAuth(selfQQ int64, secret string) gin.HandlerFunc {
+// CqhttpAuth 判断X-Self-ID是否为配置的qq账号之一，同时当X-Signature存在时，使用该账号的密钥校验签名
+func CqhttpAuth(secrets map[int64]string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		hSelfQQStr := c.GetHeader("X-Self-ID")
 		hSignature := c.GetHeader("X-Signature")
@@ -22,7 +25,8 @@ func CqhttpAuth(selfQQ int64, secret string) gin.HandlerFunc {
 			app.Unauthorized(c, e.TokenNotValid, err)
 			return
 		}
-		if selfQQ != hSelfQQ {
+		secret, ok := secrets[hSelfQQ]
+		if !ok {
 			app.Unauthorized(c, e.TokenNotValid)
 			return
 		}
@@ -51,3 +55,36 @@ func CqhttpAuth(selfQQ int64, secret string) gin.HandlerFunc {
 	}
 
 }
+
+// CqhttpWsAuth 反向websocket连接的鉴权，判断X-Self-ID是否为配置的qq账号之一，并校验 Authorization 请求头或
+// access_token 查询参数。反向websocket可以冒充任意用户发送事件，没有配置access token的账号不允许连接
+func CqhttpWsAuth(accessTokens map[int64]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hSelfQQ, err := strconv.ParseInt(c.GetHeader("X-Self-ID"), 10, 64)
+		if err != nil {
+			app.Unauthorized(c, e.TokenNotValid, err)
+			return
+		}
+		accessToken, ok := accessTokens[hSelfQQ]
+		if !ok {
+			app.Unauthorized(c, e.TokenNotValid)
+			return
+		}
+		if accessToken == "" {
+			logging.L().Warn("reject cqhttp reverse websocket, access token of the account is empty",
+				logging.Any("self_id", hSelfQQ))
+			app.Unauthorized(c, e.TokenNotValid)
+			return
+		}
+		token := c.Query("access_token")
+		if auth := c.GetHeader("Authorization"); auth != "" {
+			// 不同的onebot实现会使用 Bearer 或 Token 前缀
+			token = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(auth, "Bearer"), "Token"))
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(accessToken)) != 1 {
+			app.Unauthorized(c, e.TokenNotValid)
+			return
+		}
+		c.Next()
+	}
+}
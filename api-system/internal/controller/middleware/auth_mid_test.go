@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestCqhttpWsAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", CqhttpWsAuth(map[int64]string{1000: "wsToken", 2000: ""}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	tests := []struct {
+		selfId string
+		auth   string
+		query  string
+		ok     bool
+	}{
+		{selfId: "1000", auth: "Bearer wsToken", ok: true},
+		{selfId: "1000", auth: "Token wsToken", ok: true},
+		{selfId: "1000", query: "wsToken", ok: true},
+		{selfId: "1000", auth: "Bearer wrong", ok: false},
+		{selfId: "1000", ok: false},
+		// 没有配置access token的账号不允许连接
+		{selfId: "2000", ok: false},
+		{selfId: "2000", auth: "Bearer ", ok: false},
+		{selfId: "3000", auth: "Bearer wsToken", ok: false},
+		{selfId: "abc", auth: "Bearer wsToken", ok: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ws?access_token="+tt.query, nil)
+			req.Header.Set("X-Self-ID", tt.selfId)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.ok, w.Code == http.StatusOK)
+		})
+	}
+}
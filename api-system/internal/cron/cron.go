@@ -9,6 +9,7 @@ import (
 	"github.com/axiangcoding/antonstar-bot/pkg/cqhttp"
 	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/axiangcoding/antonstar-bot/setting"
 	"github.com/robfig/cron/v3"
 )
 
@@ -31,6 +32,15 @@ func addJob(c *cron.Cron) {
 	}); err != nil {
 		logging.L().Fatal("add cron job CheckWTNewsUpdate failed", logging.Error(err))
 	}
+	if _, err := c.AddFunc("@every 6h", RefreshGameUserRating); err != nil {
+		logging.L().Fatal("add cron job RefreshGameUserRating failed", logging.Error(err))
+	}
+	if _, err := c.AddFunc("@every 10m", RunCardTournament); err != nil {
+		logging.L().Fatal("add cron job RunCardTournament failed", logging.Error(err))
+	}
+	if _, err := c.AddFunc("@every 1m", RefreshWatchedPlayers); err != nil {
+		logging.L().Fatal("add cron job RefreshWatchedPlayers failed", logging.Error(err))
+	}
 	logging.L().Info("all cron job add success")
 }
 
@@ -79,6 +89,33 @@ func RefreshUserTodayCount() {
 	}
 }
 
+// RefreshGameUserRating 库内玩家数据变化后，重新计算所有玩家的安东星效率值
+func RefreshGameUserRating() {
+	if err := service.RefreshAllGameUserRating(); err != nil {
+		logging.L().Error("refresh all game user rating failed. ", logging.Error(err))
+	} else {
+		logging.L().Info("refresh all game user rating success")
+	}
+}
+
+// RefreshWatchedPlayers 轮流刷新订阅和关注的玩家，资料或封禁状态变化时通知到群
+func RefreshWatchedPlayers() {
+	conf := setting.C().App.PlayerRefresh
+	if err := service.RefreshWatchedPlayers(conf.Interval, conf.BatchSize); err != nil {
+		logging.L().Error("refresh watched players failed", logging.Error(err))
+	}
+}
+
+// RunCardTournament 为进行中的卡牌锦标赛进行下一轮比赛，并将本轮结果发送到群内
+func RunCardTournament() {
+	if service.IsStopAllResponse() {
+		return
+	}
+	if err := service.RunCardTournaments(); err != nil {
+		logging.L().Error("run card tournaments failed", logging.Error(err))
+	}
+}
+
 func CheckWTNewsUpdate(region string) {
 	if err := crawler.GetFirstPageNewsFromWTOfficial(region, func(news []table.GameNew) {
 		for _, item := range news {
@@ -16,59 +16,129 @@ import (
 )
 
 var (
-	Q             = new(Query)
-	GameNew       *gameNew
-	GameUser      *gameUser
-	GlobalConfig  *globalConfig
-	Mission       *mission
-	QQGroupConfig *qQGroupConfig
-	QQUserConfig  *qQUserConfig
+	Q                   = new(Query)
+	AuditLog            *auditLog
+	BanEvent            *banEvent
+	BanWatch            *banWatch
+	CardFightRecord     *cardFightRecord
+	CardFightStat       *cardFightStat
+	CardTournament      *cardTournament
+	CardTournamentEntry *cardTournamentEntry
+	GameClan            *gameClan
+	GameClanMember      *gameClanMember
+	GameNew             *gameNew
+	GameUser            *gameUser
+	GameUserSnapshot    *gameUserSnapshot
+	GameUserSource      *gameUserSource
+	GlobalConfig        *globalConfig
+	Mission             *mission
+	PlayerSubscription  *playerSubscription
+	QQGroupConfig       *qQGroupConfig
+	QQGroupMember       *qQGroupMember
+	QQUserConfig        *qQUserConfig
+	UserCard            *userCard
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	AuditLog = &Q.AuditLog
+	BanEvent = &Q.BanEvent
+	BanWatch = &Q.BanWatch
+	CardFightRecord = &Q.CardFightRecord
+	CardFightStat = &Q.CardFightStat
+	CardTournament = &Q.CardTournament
+	CardTournamentEntry = &Q.CardTournamentEntry
+	GameClan = &Q.GameClan
+	GameClanMember = &Q.GameClanMember
 	GameNew = &Q.GameNew
 	GameUser = &Q.GameUser
+	GameUserSnapshot = &Q.GameUserSnapshot
+	GameUserSource = &Q.GameUserSource
 	GlobalConfig = &Q.GlobalConfig
 	Mission = &Q.Mission
+	PlayerSubscription = &Q.PlayerSubscription
 	QQGroupConfig = &Q.QQGroupConfig
+	QQGroupMember = &Q.QQGroupMember
 	QQUserConfig = &Q.QQUserConfig
+	UserCard = &Q.UserCard
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:            db,
-		GameNew:       newGameNew(db, opts...),
-		GameUser:      newGameUser(db, opts...),
-		GlobalConfig:  newGlobalConfig(db, opts...),
-		Mission:       newMission(db, opts...),
-		QQGroupConfig: newQQGroupConfig(db, opts...),
-		QQUserConfig:  newQQUserConfig(db, opts...),
+		db:                  db,
+		AuditLog:            newAuditLog(db, opts...),
+		BanEvent:            newBanEvent(db, opts...),
+		BanWatch:            newBanWatch(db, opts...),
+		CardFightRecord:     newCardFightRecord(db, opts...),
+		CardFightStat:       newCardFightStat(db, opts...),
+		CardTournament:      newCardTournament(db, opts...),
+		CardTournamentEntry: newCardTournamentEntry(db, opts...),
+		GameClan:            newGameClan(db, opts...),
+		GameClanMember:      newGameClanMember(db, opts...),
+		GameNew:             newGameNew(db, opts...),
+		GameUser:            newGameUser(db, opts...),
+		GameUserSnapshot:    newGameUserSnapshot(db, opts...),
+		GameUserSource:      newGameUserSource(db, opts...),
+		GlobalConfig:        newGlobalConfig(db, opts...),
+		Mission:             newMission(db, opts...),
+		PlayerSubscription:  newPlayerSubscription(db, opts...),
+		QQGroupConfig:       newQQGroupConfig(db, opts...),
+		QQGroupMember:       newQQGroupMember(db, opts...),
+		QQUserConfig:        newQQUserConfig(db, opts...),
+		UserCard:            newUserCard(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	GameNew       gameNew
-	GameUser      gameUser
-	GlobalConfig  globalConfig
-	Mission       mission
-	QQGroupConfig qQGroupConfig
-	QQUserConfig  qQUserConfig
+	AuditLog            auditLog
+	BanEvent            banEvent
+	BanWatch            banWatch
+	CardFightRecord     cardFightRecord
+	CardFightStat       cardFightStat
+	CardTournament      cardTournament
+	CardTournamentEntry cardTournamentEntry
+	GameClan            gameClan
+	GameClanMember      gameClanMember
+	GameNew             gameNew
+	GameUser            gameUser
+	GameUserSnapshot    gameUserSnapshot
+	GameUserSource      gameUserSource
+	GlobalConfig        globalConfig
+	Mission             mission
+	PlayerSubscription  playerSubscription
+	QQGroupConfig       qQGroupConfig
+	QQGroupMember       qQGroupMember
+	QQUserConfig        qQUserConfig
+	UserCard            userCard
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:            db,
-		GameNew:       q.GameNew.clone(db),
-		GameUser:      q.GameUser.clone(db),
-		GlobalConfig:  q.GlobalConfig.clone(db),
-		Mission:       q.Mission.clone(db),
-		QQGroupConfig: q.QQGroupConfig.clone(db),
-		QQUserConfig:  q.QQUserConfig.clone(db),
+		db:                  db,
+		AuditLog:            q.AuditLog.clone(db),
+		BanEvent:            q.BanEvent.clone(db),
+		BanWatch:            q.BanWatch.clone(db),
+		CardFightRecord:     q.CardFightRecord.clone(db),
+		CardFightStat:       q.CardFightStat.clone(db),
+		CardTournament:      q.CardTournament.clone(db),
+		CardTournamentEntry: q.CardTournamentEntry.clone(db),
+		GameClan:            q.GameClan.clone(db),
+		GameClanMember:      q.GameClanMember.clone(db),
+		GameNew:             q.GameNew.clone(db),
+		GameUser:            q.GameUser.clone(db),
+		GameUserSnapshot:    q.GameUserSnapshot.clone(db),
+		GameUserSource:      q.GameUserSource.clone(db),
+		GlobalConfig:        q.GlobalConfig.clone(db),
+		Mission:             q.Mission.clone(db),
+		PlayerSubscription:  q.PlayerSubscription.clone(db),
+		QQGroupConfig:       q.QQGroupConfig.clone(db),
+		QQGroupMember:       q.QQGroupMember.clone(db),
+		QQUserConfig:        q.QQUserConfig.clone(db),
+		UserCard:            q.UserCard.clone(db),
 	}
 }
 
@@ -82,33 +152,75 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:            db,
-		GameNew:       q.GameNew.replaceDB(db),
-		GameUser:      q.GameUser.replaceDB(db),
-		GlobalConfig:  q.GlobalConfig.replaceDB(db),
-		Mission:       q.Mission.replaceDB(db),
-		QQGroupConfig: q.QQGroupConfig.replaceDB(db),
-		QQUserConfig:  q.QQUserConfig.replaceDB(db),
+		db:                  db,
+		AuditLog:            q.AuditLog.replaceDB(db),
+		BanEvent:            q.BanEvent.replaceDB(db),
+		BanWatch:            q.BanWatch.replaceDB(db),
+		CardFightRecord:     q.CardFightRecord.replaceDB(db),
+		CardFightStat:       q.CardFightStat.replaceDB(db),
+		CardTournament:      q.CardTournament.replaceDB(db),
+		CardTournamentEntry: q.CardTournamentEntry.replaceDB(db),
+		GameClan:            q.GameClan.replaceDB(db),
+		GameClanMember:      q.GameClanMember.replaceDB(db),
+		GameNew:             q.GameNew.replaceDB(db),
+		GameUser:            q.GameUser.replaceDB(db),
+		GameUserSnapshot:    q.GameUserSnapshot.replaceDB(db),
+		GameUserSource:      q.GameUserSource.replaceDB(db),
+		GlobalConfig:        q.GlobalConfig.replaceDB(db),
+		Mission:             q.Mission.replaceDB(db),
+		PlayerSubscription:  q.PlayerSubscription.replaceDB(db),
+		QQGroupConfig:       q.QQGroupConfig.replaceDB(db),
+		QQGroupMember:       q.QQGroupMember.replaceDB(db),
+		QQUserConfig:        q.QQUserConfig.replaceDB(db),
+		UserCard:            q.UserCard.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	GameNew       IGameNewDo
-	GameUser      IGameUserDo
-	GlobalConfig  IGlobalConfigDo
-	Mission       IMissionDo
-	QQGroupConfig IQQGroupConfigDo
-	QQUserConfig  IQQUserConfigDo
+	AuditLog            IAuditLogDo
+	BanEvent            IBanEventDo
+	BanWatch            IBanWatchDo
+	CardFightRecord     ICardFightRecordDo
+	CardFightStat       ICardFightStatDo
+	CardTournament      ICardTournamentDo
+	CardTournamentEntry ICardTournamentEntryDo
+	GameClan            IGameClanDo
+	GameClanMember      IGameClanMemberDo
+	GameNew             IGameNewDo
+	GameUser            IGameUserDo
+	GameUserSnapshot    IGameUserSnapshotDo
+	GameUserSource      IGameUserSourceDo
+	GlobalConfig        IGlobalConfigDo
+	Mission             IMissionDo
+	PlayerSubscription  IPlayerSubscriptionDo
+	QQGroupConfig       IQQGroupConfigDo
+	QQGroupMember       IQQGroupMemberDo
+	QQUserConfig        IQQUserConfigDo
+	UserCard            IUserCardDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		GameNew:       q.GameNew.WithContext(ctx),
-		GameUser:      q.GameUser.WithContext(ctx),
-		GlobalConfig:  q.GlobalConfig.WithContext(ctx),
-		Mission:       q.Mission.WithContext(ctx),
-		QQGroupConfig: q.QQGroupConfig.WithContext(ctx),
-		QQUserConfig:  q.QQUserConfig.WithContext(ctx),
+		AuditLog:            q.AuditLog.WithContext(ctx),
+		BanEvent:            q.BanEvent.WithContext(ctx),
+		BanWatch:            q.BanWatch.WithContext(ctx),
+		CardFightRecord:     q.CardFightRecord.WithContext(ctx),
+		CardFightStat:       q.CardFightStat.WithContext(ctx),
+		CardTournament:      q.CardTournament.WithContext(ctx),
+		CardTournamentEntry: q.CardTournamentEntry.WithContext(ctx),
+		GameClan:            q.GameClan.WithContext(ctx),
+		GameClanMember:      q.GameClanMember.WithContext(ctx),
+		GameNew:             q.GameNew.WithContext(ctx),
+		GameUser:            q.GameUser.WithContext(ctx),
+		GameUserSnapshot:    q.GameUserSnapshot.WithContext(ctx),
+		GameUserSource:      q.GameUserSource.WithContext(ctx),
+		GlobalConfig:        q.GlobalConfig.WithContext(ctx),
+		Mission:             q.Mission.WithContext(ctx),
+		PlayerSubscription:  q.PlayerSubscription.WithContext(ctx),
+		QQGroupConfig:       q.QQGroupConfig.WithContext(ctx),
+		QQGroupMember:       q.QQGroupMember.WithContext(ctx),
+		QQUserConfig:        q.QQUserConfig.WithContext(ctx),
+		UserCard:            q.UserCard.WithContext(ctx),
 	}
 }
 
@@ -38,6 +38,12 @@ func newMission(db *gorm.DB, opts ...gen.DOOption) mission {
 	_mission.Process = field.NewFloat64(tableName, "process")
 	_mission.Detail = field.NewString(tableName, "detail")
 	_mission.Result = field.NewString(tableName, "result")
+	_mission.Attempts = field.NewInt(tableName, "attempts")
+	_mission.NextRunAt = field.NewTime(tableName, "next_run_at")
+	_mission.LeaseOwner = field.NewString(tableName, "lease_owner")
+	_mission.LeaseUntil = field.NewTime(tableName, "lease_until")
+	_mission.LastError = field.NewString(tableName, "last_error")
+	_mission.DedupKey = field.NewString(tableName, "dedup_key")
 
 	_mission.fillFieldMap()
 
@@ -59,6 +65,12 @@ type mission struct {
 	Process      field.Float64
 	Detail       field.String
 	Result       field.String
+	Attempts     field.Int
+	NextRunAt    field.Time
+	LeaseOwner   field.String
+	LeaseUntil   field.Time
+	LastError    field.String
+	DedupKey     field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -86,6 +98,12 @@ func (m *mission) updateTableName(table string) *mission {
 	m.Process = field.NewFloat64(table, "process")
 	m.Detail = field.NewString(table, "detail")
 	m.Result = field.NewString(table, "result")
+	m.Attempts = field.NewInt(table, "attempts")
+	m.NextRunAt = field.NewTime(table, "next_run_at")
+	m.LeaseOwner = field.NewString(table, "lease_owner")
+	m.LeaseUntil = field.NewTime(table, "lease_until")
+	m.LastError = field.NewString(table, "last_error")
+	m.DedupKey = field.NewString(table, "dedup_key")
 
 	m.fillFieldMap()
 
@@ -102,7 +120,7 @@ func (m *mission) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (m *mission) fillFieldMap() {
-	m.fieldMap = make(map[string]field.Expr, 11)
+	m.fieldMap = make(map[string]field.Expr, 17)
 	m.fieldMap["id"] = m.ID
 	m.fieldMap["created_at"] = m.CreatedAt
 	m.fieldMap["updated_at"] = m.UpdatedAt
@@ -114,6 +132,12 @@ func (m *mission) fillFieldMap() {
 	m.fieldMap["process"] = m.Process
 	m.fieldMap["detail"] = m.Detail
 	m.fieldMap["result"] = m.Result
+	m.fieldMap["attempts"] = m.Attempts
+	m.fieldMap["next_run_at"] = m.NextRunAt
+	m.fieldMap["lease_owner"] = m.LeaseOwner
+	m.fieldMap["lease_until"] = m.LeaseUntil
+	m.fieldMap["last_error"] = m.LastError
+	m.fieldMap["dedup_key"] = m.DedupKey
 }
 
 func (m mission) clone(db *gorm.DB) mission {
@@ -42,6 +42,7 @@ func newQQUserConfig(db *gorm.DB, opts ...gen.DOOption) qQUserConfig {
 	_qQUserConfig.Admin = field.NewBool(tableName, "admin")
 	_qQUserConfig.SuperAdmin = field.NewBool(tableName, "super_admin")
 	_qQUserConfig.BindingGameNick = field.NewString(tableName, "binding_game_nick")
+	_qQUserConfig.BindingVerified = field.NewBool(tableName, "binding_verified")
 
 	_qQUserConfig.fillFieldMap()
 
@@ -67,6 +68,7 @@ type qQUserConfig struct {
 	Admin            field.Bool
 	SuperAdmin       field.Bool
 	BindingGameNick  field.String
+	BindingVerified  field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -98,6 +100,7 @@ func (q *qQUserConfig) updateTableName(table string) *qQUserConfig {
 	q.Admin = field.NewBool(table, "admin")
 	q.SuperAdmin = field.NewBool(table, "super_admin")
 	q.BindingGameNick = field.NewString(table, "binding_game_nick")
+	q.BindingVerified = field.NewBool(table, "binding_verified")
 
 	q.fillFieldMap()
 
@@ -114,7 +117,7 @@ func (q *qQUserConfig) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (q *qQUserConfig) fillFieldMap() {
-	q.fieldMap = make(map[string]field.Expr, 15)
+	q.fieldMap = make(map[string]field.Expr, 16)
 	q.fieldMap["id"] = q.ID
 	q.fieldMap["created_at"] = q.CreatedAt
 	q.fieldMap["updated_at"] = q.UpdatedAt
@@ -130,6 +133,7 @@ func (q *qQUserConfig) fillFieldMap() {
 	q.fieldMap["admin"] = q.Admin
 	q.fieldMap["super_admin"] = q.SuperAdmin
 	q.fieldMap["binding_game_nick"] = q.BindingGameNick
+	q.fieldMap["binding_verified"] = q.BindingVerified
 }
 
 func (q qQUserConfig) clone(db *gorm.DB) qQUserConfig {
@@ -52,6 +52,20 @@ func autoMigrate(db *gorm.DB) {
 		&table.QQUserConfig{},
 		&table.GlobalConfig{},
 		&table.GameNew{},
+		&table.AuditLog{},
+		&table.GameUserSnapshot{},
+		&table.UserCard{},
+		&table.CardFightStat{},
+		&table.CardFightRecord{},
+		&table.CardTournament{},
+		&table.CardTournamentEntry{},
+		&table.GameUserSource{},
+		&table.GameClan{},
+		&table.GameClanMember{},
+		&table.QQGroupMember{},
+		&table.PlayerSubscription{},
+		&table.BanWatch{},
+		&table.BanEvent{},
 	); err != nil {
 		logging.L().Fatal("auto migrate error", logging.Error(err))
 	} else {
@@ -87,6 +101,20 @@ func GenCode(db *gorm.DB) {
 		table.QQUserConfig{},
 		table.GlobalConfig{},
 		table.GameNew{},
+		table.AuditLog{},
+		table.GameUserSnapshot{},
+		table.UserCard{},
+		table.CardFightStat{},
+		table.CardFightRecord{},
+		table.CardTournament{},
+		table.CardTournamentEntry{},
+		table.GameUserSource{},
+		table.GameClan{},
+		table.GameClanMember{},
+		table.QQGroupMember{},
+		table.PlayerSubscription{},
+		table.BanWatch{},
+		table.BanEvent{},
 	)
 
 	// Execute the generator
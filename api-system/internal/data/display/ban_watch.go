@@ -0,0 +1,55 @@
+package display
+
+type BanWatch struct {
+	Nick      string `json:"nick"`
+	Banned    bool   `json:"banned"`
+	CreatedAt string `json:"created_at"`
+	// 上次检查的时间，还没有检查过时为空
+	CheckedAt string `json:"checked_at,omitempty"`
+}
+
+type BanWatchList struct {
+	Max     int        `json:"max"`
+	Watches []BanWatch `json:"watches"`
+}
+
+type BanEvent struct {
+	Nick   string   `json:"nick"`
+	Banned bool     `json:"banned"`
+	Clan   string   `json:"clan"`
+	Level  int      `json:"level"`
+	StatAb UserStat `json:"stat_ab"`
+	StatRb UserStat `json:"stat_rb"`
+	StatSb UserStat `json:"stat_sb"`
+	// 最后已知数据的时间，没有数据时为空
+	StatAt     string `json:"stat_at,omitempty"`
+	DetectedAt string `json:"detected_at"`
+}
+
+const templateBanWatchListStr = `
+本群关注的玩家（{{len .Watches}}/{{.Max}}）：
+{{- range .Watches}}
+{{.Nick}} {{if .CheckedAt}}{{if .Banned}}已封禁{{else}}正常{{end}}{{else}}等待检查{{end}}
+{{- end}}
+`
+
+const templateBanEventStr = `
+关注的玩家 {{.Nick}} {{if .Banned}}已被封禁{{else}}已解除封禁{{end}}
+联队: {{.Clan}}
+等级: {{.Level}}
+{{- if .StatAt}}
+最后已知数据（{{.StatAt}}）:
+街机: {{.StatAb.TotalMission}} 场任务, 胜率 {{.StatAb.WinRate}}, KD {{.StatAb.Kd}}
+历史: {{.StatRb.TotalMission}} 场任务, 胜率 {{.StatRb.WinRate}}, KD {{.StatRb.Kd}}
+全真: {{.StatSb.TotalMission}} 场任务, 胜率 {{.StatSb.WinRate}}, KD {{.StatSb.Kd}}
+{{- end}}
+发现时间: {{.DetectedAt}}
+`
+
+func (l BanWatchList) ToFriendlyString() string {
+	return parseTemplate(templateBanWatchListStr, l)
+}
+
+func (e BanEvent) ToFriendlyString() string {
+	return parseTemplate(templateBanEventStr, e)
+}
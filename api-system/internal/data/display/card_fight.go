@@ -0,0 +1,128 @@
+package display
+
+type UserCard struct {
+	Name         string  `json:"name"`
+	Class        string  `json:"class"`
+	Nation       string  `json:"nation"`
+	BattleRating float64 `json:"battle_rating"`
+	Rarity       string  `json:"rarity"`
+	Velocity     float64 `json:"velocity"`
+	Firepower    float64 `json:"firepower"`
+	Protection   float64 `json:"protection"`
+}
+
+// CatalogueCard 卡牌图鉴中的卡牌
+type CatalogueCard struct {
+	Name         string  `json:"name"`
+	Nation       string  `json:"nation"`
+	BattleRating float64 `json:"battle_rating"`
+	Rank         int     `json:"rank"`
+	Class        string  `json:"class"`
+	Velocity     float64 `json:"velocity"`
+	Firepower    float64 `json:"firepower"`
+	Protection   float64 `json:"protection"`
+	Rarity       string  `json:"rarity"`
+	// 抽到这张卡牌的概率
+	Probability float64 `json:"probability"`
+}
+
+type UserCards struct {
+	Cards []UserCard `json:"cards"`
+}
+
+type CardFightStat struct {
+	Rank   int   `json:"rank"`
+	UserId int64 `json:"user_id"`
+	Win    int   `json:"win"`
+	Lose   int   `json:"lose"`
+	Draw   int   `json:"draw"`
+}
+
+type CardFightRank struct {
+	Stats []CardFightStat `json:"stats"`
+}
+
+const templateUserCardStr = `[{{.Rarity}}]{{.Name}}（{{if .Nation}}{{.Nation}} {{printf "%.1f" .BattleRating}} {{end}}{{.Class}}，机动{{.Velocity}}/火力{{.Firepower}}/防护{{.Protection}}）`
+
+const templateUserCardsStr = `
+共有{{len .Cards}}张卡牌：
+{{- range .Cards}}
+- [{{.Rarity}}]{{.Name}}（{{if .Nation}}{{.Nation}} {{printf "%.1f" .BattleRating}} {{end}}{{.Class}}，机动{{.Velocity}}/火力{{.Firepower}}/防护{{.Protection}}）
+{{- end}}
+`
+
+const templateCardFightRankStr = `
+本群卡牌对战排行：
+{{- range .Stats}}
+{{.Rank}}. {{.UserId}} 胜{{.Win}} 负{{.Lose}} 平{{.Draw}}
+{{- end}}
+`
+
+func (c UserCard) ToFriendlyString() string {
+	return parseTemplate(templateUserCardStr, c)
+}
+
+func (c UserCards) ToFriendlyString() string {
+	return parseTemplate(templateUserCardsStr, c)
+}
+
+func (r CardFightRank) ToFriendlyString() string {
+	return parseTemplate(templateCardFightRankStr, r)
+}
+
+type CardTournamentMatch struct {
+	RecordId uint   `json:"record_id"`
+	UserA    string `json:"user_a"`
+	CardA    string `json:"card_a"`
+	UserB    string `json:"user_b"`
+	CardB    string `json:"card_b"`
+	Winner   string `json:"winner"`
+	// 平局时抽签决定晋级的一方
+	Draw bool `json:"draw"`
+}
+
+type CardTournamentRound struct {
+	Round    int                   `json:"round"`
+	Matches  []CardTournamentMatch `json:"matches"`
+	Bye      string                `json:"bye,omitempty"`
+	Champion string                `json:"champion,omitempty"`
+}
+
+type CardTournament struct {
+	Id      uint     `json:"id"`
+	Status  string   `json:"status"`
+	Round   int      `json:"round"`
+	Entries []string `json:"entries"`
+}
+
+const templateCardTournamentRoundStr = `
+卡牌锦标赛第{{.Round}}轮结果：
+{{- range .Matches}}
+{{.UserA}}（{{.CardA}}） VS {{.UserB}}（{{.CardB}}）：{{if .Draw}}平局，抽签决定{{end}}{{.Winner}} 晋级，对战编号 {{.RecordId}}
+{{- end}}
+{{- if .Bye}}
+{{.Bye}} 本轮轮空，直接晋级
+{{- end}}
+{{- if .Champion}}
+
+恭喜 {{.Champion}} 获得本次锦标赛的冠军！
+{{- end}}
+`
+
+const templateCardTournamentStr = `
+卡牌锦标赛（编号 {{.Id}}）
+状态：{{.Status}}
+已进行轮次：{{.Round}}
+报名人数：{{len .Entries}}
+{{- range .Entries}}
+- {{.}}
+{{- end}}
+`
+
+func (r CardTournamentRound) ToFriendlyString() string {
+	return parseTemplate(templateCardTournamentRoundStr, r)
+}
+
+func (t CardTournament) ToFriendlyString() string {
+	return parseTemplate(templateCardTournamentStr, t)
+}
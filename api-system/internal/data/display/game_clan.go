@@ -0,0 +1,90 @@
+package display
+
+type GameClan struct {
+	Tag          string `json:"tag"`
+	Name         string `json:"name"`
+	Url          string `json:"url"`
+	Description  string `json:"description"`
+	RegisterDate string `json:"register_date"`
+	MemberCount  int    `json:"member_count"`
+	UpdatedAt    string `json:"updated_at"`
+	// 以下为库内收录的成员数据的统计
+	RecordedCount int    `json:"recorded_count"`
+	AvgLevel      string `json:"avg_level"`
+	WinRateAb     string `json:"win_rate_ab"`
+	WinRateRb     string `json:"win_rate_rb"`
+	WinRateSb     string `json:"win_rate_sb"`
+	AsRateAb      string `json:"as_rate_ab"`
+	AsRateRb      string `json:"as_rate_rb"`
+	AsRateSb      string `json:"as_rate_sb"`
+	// 联队页面中的成员列表，按个人联队评分从高到低排序
+	Members []ClanMember `json:"members"`
+}
+
+type ClanMember struct {
+	Nick     string `json:"nick"`
+	Role     string `json:"role"`
+	Rating   int    `json:"rating"`
+	Activity int    `json:"activity"`
+	JoinDate string `json:"join_date"`
+	// 库内是否收录了该成员的数据，收录时才有以下字段
+	Recorded  bool   `json:"recorded"`
+	Level     int    `json:"level,omitempty"`
+	WinRateRb string `json:"win_rate_rb,omitempty"`
+}
+
+type ClanRankItem struct {
+	Rank          int    `json:"rank"`
+	Tag           string `json:"tag"`
+	RecordedCount int    `json:"recorded_count"`
+	WinRateRb     string `json:"win_rate_rb"`
+	AsRateRb      string `json:"as_rate_rb"`
+}
+
+type ClanRank struct {
+	MinMember int            `json:"min_member"`
+	Items     []ClanRankItem `json:"items"`
+}
+
+const templateGameClanStr = `
+联队: {{.Tag}} {{.Name}}
+{{- if .Description}}
+简介: {{.Description}}
+{{- end}}
+创建日期: {{.RegisterDate}}
+成员数: {{.MemberCount}}
+联队页面: {{.Url}}
+{{- if .RecordedCount}}
+
+已收录{{.RecordedCount}}名成员的数据
+平均等级: {{.AvgLevel}}
+平均胜率: 街机 {{.WinRateAb}} / 历史 {{.WinRateRb}} / 全真 {{.WinRateSb}}
+平均安东星效率值: 街机 {{.AsRateAb}} / 历史 {{.AsRateRb}} / 全真 {{.AsRateSb}}
+{{- else}}
+
+还没有收录该联队成员的数据，查询成员后再来看看吧
+{{- end}}
+{{- if .Members}}
+
+联队评分前{{if lt (len .Members) 10}}{{len .Members}}{{else}}10{{end}}的成员：
+{{- range $i, $m := .Members}}{{if lt $i 10}}
+{{$m.Nick}} {{$m.Role}} 评分{{$m.Rating}}{{if $m.Recorded}} 等级{{$m.Level}} 历史胜率{{$m.WinRateRb}}{{end}}
+{{- end}}{{end}}
+{{- end}}
+数据最后刷新时间: {{.UpdatedAt}}
+`
+
+const templateClanRankStr = `
+联队排行（按成员的历史平均胜率，至少收录{{.MinMember}}名成员）：
+{{- range .Items}}
+{{.Rank}}. {{.Tag}} 胜率{{.WinRateRb}} 效率值{{.AsRateRb}} 收录成员{{.RecordedCount}}
+{{- end}}
+`
+
+func (c GameClan) ToFriendlyString() string {
+	return parseTemplate(templateGameClanStr, c)
+}
+
+func (r ClanRank) ToFriendlyString() string {
+	return parseTemplate(templateClanRankStr, r)
+}
@@ -32,6 +32,8 @@ type GameUser struct {
 	AsRBRate float64 `json:"as_rb_rate"`
 	AsSBRate float64 `json:"as_sb_rate"`
 	Banned   bool    `json:"banned"`
+	// QQ用户绑定该游戏昵称的状态，没有用户绑定时为空
+	BindingState string `json:"binding_state,omitempty"`
 }
 
 type UserStat struct {
@@ -112,6 +114,9 @@ const templateShortStr = `
 注册时间: {{.RegisterDate}}
 等级: {{.Level}}
 头衔: {{.Title}}
+{{- if .BindingState}}
+绑定状态: {{.BindingState}}
+{{- end}}
 {{if .Banned}}==== 已被封禁 ===={{end}}
 
 街机任务数: {{.StatAb.TotalMission}}
@@ -129,6 +134,11 @@ TS街机效率: {{.TsABRate}}%
 TS历史效率: {{.TsRBRate}}%
 TS全真效率: {{.TsSBRate}}%
 
+（安东星效率值根据库内玩家数据计算，任务数过少时暂不计算）
+安东星街机效率: {{if .AsABRate}}{{.AsABRate}}%{{else}}暂无{{end}}
+安东星历史效率: {{if .AsRBRate}}{{.AsRBRate}}%{{else}}暂无{{end}}
+安东星全真效率: {{if .AsSBRate}}{{.AsSBRate}}%{{else}}暂无{{end}}
+
 数据最后刷新时间: {{.UpdatedAt}}
 `
 
@@ -139,6 +149,9 @@ const templateFullStr = `
 注册时间: {{.RegisterDate}}
 等级: {{.Level}}
 头衔: {{.Title}}
+{{- if .BindingState}}
+绑定状态: {{.BindingState}}
+{{- end}}
 {{if .Banned}}==== 已被封禁 ===={{end}}
 
 街机任务数: {{.StatAb.TotalMission}}
@@ -172,6 +185,11 @@ TS街机效率: {{.TsABRate}}%
 TS历史效率: {{.TsRBRate}}%
 TS全真效率: {{.TsSBRate}}%
 
+（安东星效率值根据库内玩家数据计算，任务数过少时暂不计算）
+安东星街机效率: {{if .AsABRate}}{{.AsABRate}}%{{else}}暂无{{end}}
+安东星历史效率: {{if .AsRBRate}}{{.AsRBRate}}%{{else}}暂无{{end}}
+安东星全真效率: {{if .AsSBRate}}{{.AsSBRate}}%{{else}}暂无{{end}}
+
 数据最后刷新时间: {{.UpdatedAt}}
 `
 
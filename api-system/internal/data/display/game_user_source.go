@@ -0,0 +1,11 @@
+package display
+
+import "time"
+
+// GameUserSource 玩家数据在一个来源的获取情况
+type GameUserSource struct {
+	Source    string    `json:"source"`
+	Found     bool      `json:"found"`
+	FetchedAt time.Time `json:"fetched_at"`
+	LastError string    `json:"last_error,omitempty"`
+}
@@ -0,0 +1,28 @@
+package display
+
+type LeaderboardItem struct {
+	Rank  int    `json:"rank"`
+	Nick  string `json:"nick"`
+	Value string `json:"value"`
+}
+
+type Leaderboard struct {
+	Metric     string `json:"metric"`
+	MetricName string `json:"metric_name"`
+	Mode       string `json:"mode"`
+	ModeName   string `json:"mode_name"`
+	// 参与排行的玩家数
+	Total int               `json:"total"`
+	Items []LeaderboardItem `json:"items"`
+}
+
+const templateGroupLeaderboardStr = `
+本群{{.ModeName}}{{.MetricName}}排行（共{{.Total}}名绑定了游戏昵称的群友上榜）：
+{{- range .Items}}
+{{.Rank}}. {{.Nick}} {{.Value}}
+{{- end}}
+`
+
+func (l Leaderboard) ToFriendlyString() string {
+	return parseTemplate(templateGroupLeaderboardStr, l)
+}
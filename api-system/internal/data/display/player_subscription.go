@@ -0,0 +1,23 @@
+package display
+
+type PlayerChange struct {
+	Nick string `json:"nick"`
+	Item string `json:"item"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type PlayerChangeDigest struct {
+	Changes []PlayerChange `json:"changes"`
+}
+
+const templatePlayerChangeDigestStr = `
+订阅玩家动态：
+{{- range .Changes}}
+{{.Nick}} {{.Item}}: {{if .From}}{{.From}}{{else}}无{{end}} → {{if .To}}{{.To}}{{else}}无{{end}}
+{{- end}}
+`
+
+func (d PlayerChangeDigest) ToFriendlyString() string {
+	return parseTemplate(templatePlayerChangeDigestStr, d)
+}
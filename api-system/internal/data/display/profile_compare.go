@@ -0,0 +1,42 @@
+package display
+
+const (
+	CompareTie = iota
+	CompareBetterA
+	CompareBetterB
+)
+
+type ProfileCompare struct {
+	NickA    string           `json:"nick_a"`
+	NickB    string           `json:"nick_b"`
+	Sections []CompareSection `json:"sections"`
+}
+
+type CompareSection struct {
+	Title string       `json:"title"`
+	Rows  []CompareRow `json:"rows"`
+}
+
+type CompareRow struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+	// Better 数值更好的一方，相同时为 CompareTie
+	Better int `json:"better"`
+}
+
+const templateProfileCompareStr = `
+{{.NickA}} VS {{.NickB}}
+（✔ 标记数值更好的一方）
+{{- range .Sections}}
+
+== {{.Title}} ==
+{{- range .Rows}}
+{{.Name}}: {{.A}}{{if eq .Better 1}} ✔{{end}} | {{.B}}{{if eq .Better 2}} ✔{{end}}
+{{- end}}
+{{- end}}
+`
+
+func (c ProfileCompare) ToFriendlyString() string {
+	return parseTemplate(templateProfileCompareStr, c)
+}
@@ -0,0 +1,52 @@
+package display
+
+type ProfileTrend struct {
+	Nick       string    `json:"nick"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Days       int       `json:"days"`
+	LevelDelta int       `json:"level_delta"`
+	Ab         StatTrend `json:"ab"`
+	Rb         StatTrend `json:"rb"`
+	Sb         StatTrend `json:"sb"`
+}
+
+type StatTrend struct {
+	MissionDelta int    `json:"mission_delta"`
+	WinDelta     int    `json:"win_delta"`
+	WinRateFrom  string `json:"win_rate_from"`
+	WinRateTo    string `json:"win_rate_to"`
+	KdFrom       string `json:"kd_from"`
+	KdTo         string `json:"kd_to"`
+}
+
+const templateProfileTrendStr = `
+游戏昵称: {{.Nick}}
+统计区间: {{.From}} ~ {{.To}}（{{.Days}}天）
+等级变化: {{printf "%+d" .LevelDelta}}
+{{- if not (or .Ab.MissionDelta .Rb.MissionDelta .Sb.MissionDelta)}}
+期间没有新的对局记录
+{{- end}}
+{{- with .Ab}}{{if .MissionDelta}}
+
+街机: {{printf "%+d" .MissionDelta}} 场任务, {{printf "%+d" .WinDelta}} 场胜利
+街机胜率: {{.WinRateFrom}} → {{.WinRateTo}}
+街机KD: {{.KdFrom}} → {{.KdTo}}
+{{- end}}{{end}}
+{{- with .Rb}}{{if .MissionDelta}}
+
+历史: {{printf "%+d" .MissionDelta}} 场任务, {{printf "%+d" .WinDelta}} 场胜利
+历史胜率: {{.WinRateFrom}} → {{.WinRateTo}}
+历史KD: {{.KdFrom}} → {{.KdTo}}
+{{- end}}{{end}}
+{{- with .Sb}}{{if .MissionDelta}}
+
+全真: {{printf "%+d" .MissionDelta}} 场任务, {{printf "%+d" .WinDelta}} 场胜利
+全真胜率: {{.WinRateFrom}} → {{.WinRateTo}}
+全真KD: {{.KdFrom}} → {{.KdTo}}
+{{- end}}{{end}}
+`
+
+func (t ProfileTrend) ToFriendlyString() string {
+	return parseTemplate(templateProfileTrendStr, t)
+}
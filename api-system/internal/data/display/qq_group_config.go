@@ -10,6 +10,7 @@ type QQGroupConfig struct {
 	EnableActionLuck    bool
 	EnableActionSetting bool
 	EnableCheckBiliRoom bool
+	EnableCheckWTNew    bool
 	MessageTemplate     int
 }
 
@@ -23,6 +24,7 @@ QQ群号: {{.GroupId}}
 启用气运功能: {{if .EnableActionLuck}} 是 {{else}} 否 {{end}}
 启用配置设置功能: {{if .EnableActionSetting}} 是 {{else}} 否 {{end}}
 启用直播间检查功能: {{if .EnableCheckBiliRoom}} 是 {{else}} 否 {{end}}
+启用官网新闻推送功能: {{if .EnableCheckWTNew}} 是 {{else}} 否 {{end}}
 语气类型: {{.MessageTemplate}}
 {{if .Banned}}==== 本群已被禁用功能 ===={{end}}
 `
@@ -0,0 +1,23 @@
+package table
+
+import "gorm.io/gorm"
+
+const (
+	AuditTargetUser   = "user"
+	AuditTargetGroup  = "group"
+	AuditTargetGlobal = "global"
+)
+
+// AuditLog 记录超级管理员通过机器人命令修改配置的操作
+type AuditLog struct {
+	gorm.Model
+	OperatorId int64  `gorm:"index"`
+	Platform   string `gorm:"size:255"`
+	// 执行命令时所在的群，私聊时为0
+	GroupId    int64
+	Action     string `gorm:"index;size:255"`
+	TargetType string `gorm:"size:255"`
+	TargetId   int64  `gorm:"index"`
+	OldValue   string
+	NewValue   string
+}
@@ -0,0 +1,93 @@
+package table
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"gorm.io/gorm"
+	"time"
+)
+
+// BanWatch 群内关注的玩家，定时检查封禁状态，状态变化时通知到群
+type BanWatch struct {
+	gorm.Model
+	GroupId int64  `gorm:"uniqueIndex:idx_ban_watch_group_nick"`
+	Nick    string `gorm:"uniqueIndex:idx_ban_watch_group_nick;size:255"`
+	// 添加关注时使用的平台和机器人账号，用于发送通知
+	Platform        string `gorm:"size:255"`
+	SelfId          int64
+	MessageTemplate int
+	CreatorId       int64
+	// 上次检查时的封禁状态
+	Banned bool
+	// 上次检查的时间，为零值时还没有可以对比的状态
+	CheckedAt time.Time `gorm:"index"`
+}
+
+// BanEvent 关注的玩家封禁状态的变化记录，保存变化前最后一次已知的数据
+type BanEvent struct {
+	gorm.Model
+	GroupId int64  `gorm:"index"`
+	Nick    string `gorm:"size:255"`
+	// 变化后的封禁状态
+	Banned bool
+	Clan   string `gorm:"size:255"`
+	Level  int
+	StatAb UserStat `gorm:"embedded;embeddedPrefix:stat_ab_"`
+	StatRb UserStat `gorm:"embedded;embeddedPrefix:stat_rb_"`
+	StatSb UserStat `gorm:"embedded;embeddedPrefix:stat_sb_"`
+	// 最后已知数据的时间
+	StatAt time.Time
+}
+
+// Check 对比玩家当前的封禁状态，状态变化时返回变化记录，stat为变化前最后一次已知的数据。
+// 第一次检查只记录状态，不产生变化
+func (w *BanWatch) Check(u GameUser, stat GameUserSnapshot, now time.Time) *BanEvent {
+	banned := u.Banned != nil && *u.Banned
+	var event *BanEvent
+	if !w.CheckedAt.IsZero() && banned != w.Banned {
+		event = &BanEvent{
+			GroupId: w.GroupId,
+			Nick:    w.Nick,
+			Banned:  banned,
+			Clan:    u.Clan,
+			Level:   u.Level,
+			StatAb:  stat.StatAb,
+			StatRb:  stat.StatRb,
+			StatSb:  stat.StatSb,
+			StatAt:  stat.CreatedAt,
+		}
+	}
+	w.Banned = banned
+	w.CheckedAt = now
+	return event
+}
+
+func (w BanWatch) ToDisplay() display.BanWatch {
+	zone := time.FixedZone("CST", 8*3600)
+	ret := display.BanWatch{
+		Nick:      w.Nick,
+		Banned:    w.Banned,
+		CreatedAt: w.CreatedAt.In(zone).Format("2006-01-02 15:04:05"),
+	}
+	if !w.CheckedAt.IsZero() {
+		ret.CheckedAt = w.CheckedAt.In(zone).Format("2006-01-02 15:04:05")
+	}
+	return ret
+}
+
+func (e BanEvent) ToDisplay() display.BanEvent {
+	zone := time.FixedZone("CST", 8*3600)
+	ret := display.BanEvent{
+		Nick:       e.Nick,
+		Banned:     e.Banned,
+		Clan:       e.Clan,
+		Level:      e.Level,
+		StatAb:     convertToStat(e.StatAb),
+		StatRb:     convertToStat(e.StatRb),
+		StatSb:     convertToStat(e.StatSb),
+		DetectedAt: e.CreatedAt.In(zone).Format("2006-01-02 15:04:05"),
+	}
+	if !e.StatAt.IsZero() {
+		ret.StatAt = e.StatAt.In(zone).Format("2006-01-02 15:04:05")
+	}
+	return ret
+}
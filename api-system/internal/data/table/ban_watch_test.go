@@ -0,0 +1,44 @@
+package table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBanWatchCheck(t *testing.T) {
+	yes, no := true, false
+	checkedAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	now := checkedAt.Add(time.Hour * 6)
+	stat := GameUserSnapshot{
+		StatRb: UserStat{TotalMission: 120, WinRate: 0.55},
+	}
+	stat.CreatedAt = checkedAt
+	tests := []struct {
+		watch BanWatch
+		user  GameUser
+		want  *BanEvent
+	}{
+		// 第一次检查只记录状态
+		{watch: BanWatch{GroupId: 1, Nick: "Cheater42"}, user: GameUser{Nick: "Cheater42", Banned: &yes}, want: nil},
+		{watch: BanWatch{GroupId: 1, Nick: "Cheater42", CheckedAt: checkedAt}, user: GameUser{Nick: "Cheater42", Banned: &no}, want: nil},
+		{watch: BanWatch{GroupId: 1, Nick: "Cheater42", CheckedAt: checkedAt}, user: GameUser{Nick: "Cheater42"}, want: nil},
+		{watch: BanWatch{GroupId: 1, Nick: "Cheater42", CheckedAt: checkedAt}, user: GameUser{Nick: "Cheater42", Clan: "-RB-", Level: 100, Banned: &yes}, want: &BanEvent{
+			GroupId: 1, Nick: "Cheater42", Banned: true, Clan: "-RB-", Level: 100, StatRb: stat.StatRb, StatAt: checkedAt,
+		}},
+		{watch: BanWatch{GroupId: 1, Nick: "Cheater42", Banned: true, CheckedAt: checkedAt}, user: GameUser{Nick: "Cheater42", Banned: &no}, want: &BanEvent{
+			GroupId: 1, Nick: "Cheater42", Banned: false, StatRb: stat.StatRb, StatAt: checkedAt,
+		}},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			watch := tt.watch
+			assert.Equal(t, tt.want, watch.Check(tt.user, stat, now))
+			assert.Equal(t, now, watch.CheckedAt)
+			assert.Equal(t, tt.user.Banned != nil && *tt.user.Banned, watch.Banned)
+			// 状态已经记录，再次检查不会重复产生变化
+			assert.Nil(t, watch.Check(tt.user, stat, now))
+		})
+	}
+}
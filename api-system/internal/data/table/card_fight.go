@@ -0,0 +1,146 @@
+package table
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+// UserCard 用户抽到的载具卡牌
+type UserCard struct {
+	gorm.Model
+	UserId     int64  `gorm:"index"`
+	Name       string `gorm:"size:255"`
+	Class      string `gorm:"size:255"`
+	Velocity   float64
+	Firepower  float64
+	Protection float64
+	// 抽卡时卡牌图鉴中的信息
+	Nation       string `gorm:"size:255"`
+	BattleRating float64
+	Rarity       string `gorm:"size:255"`
+}
+
+func NewUserCard(userId int64, card cardfight.Card) UserCard {
+	return UserCard{
+		UserId:       userId,
+		Name:         card.Name,
+		Class:        card.Class,
+		Velocity:     card.Velocity,
+		Firepower:    card.Firepower,
+		Protection:   card.Protection,
+		Nation:       card.Nation,
+		BattleRating: card.BattleRating,
+		Rarity:       card.Rarity,
+	}
+}
+
+// defaultMemberProficiency 成员熟练度，目前所有卡牌相同
+const defaultMemberProficiency = 10
+
+func (c UserCard) ToCard() cardfight.Card {
+	return cardfight.Card{
+		Name:       c.Name,
+		Class:      c.Class,
+		Velocity:   c.Velocity,
+		Firepower:  c.Firepower,
+		Protection: c.Protection,
+	}
+}
+
+// newCardItem 生成用于对战的卡牌，以用户id作为卡牌的使用者名称
+func newCardItem(card cardfight.Card, userId int64) cardfight.CardAction {
+	return card.NewItem(strconv.FormatInt(userId, 10), defaultMemberProficiency)
+}
+
+// NewItem 生成由卡牌主人使用的对战卡牌
+func (c UserCard) NewItem() cardfight.CardAction {
+	return newCardItem(c.ToCard(), c.UserId)
+}
+
+func (c UserCard) ToFightCard() FightCard {
+	return FightCard{
+		Name:       c.Name,
+		Class:      c.Class,
+		Velocity:   c.Velocity,
+		Firepower:  c.Firepower,
+		Protection: c.Protection,
+	}
+}
+
+func (c UserCard) ToDisplay() display.UserCard {
+	return display.UserCard{
+		Name:         c.Name,
+		Class:        cardfight.ClassName(c.Class),
+		Nation:       c.Nation,
+		BattleRating: c.BattleRating,
+		Rarity:       cardfight.RarityName(c.Rarity),
+		Velocity:     c.Velocity,
+		Firepower:    c.Firepower,
+		Protection:   c.Protection,
+	}
+}
+
+// CardFightStat 用户在群内的卡牌对战战绩
+type CardFightStat struct {
+	gorm.Model
+	GroupId int64 `gorm:"uniqueIndex:idx_card_fight_stat_group_user"`
+	UserId  int64 `gorm:"uniqueIndex:idx_card_fight_stat_group_user"`
+	Win     int
+	Lose    int
+	Draw    int
+}
+
+func (s CardFightStat) ToDisplay() display.CardFightStat {
+	return display.CardFightStat{
+		UserId: s.UserId,
+		Win:    s.Win,
+		Lose:   s.Lose,
+		Draw:   s.Draw,
+	}
+}
+
+// FightCard 对战时双方使用的卡牌，单独保存以免卡牌数值变化后无法回放
+type FightCard struct {
+	Name       string `gorm:"size:255"`
+	Class      string `gorm:"size:255"`
+	Velocity   float64
+	Firepower  float64
+	Protection float64
+}
+
+func (c FightCard) ToCard() cardfight.Card {
+	return cardfight.Card{
+		Name:       c.Name,
+		Class:      c.Class,
+		Velocity:   c.Velocity,
+		Firepower:  c.Firepower,
+		Protection: c.Protection,
+	}
+}
+
+// CardFightRecord 卡牌对战记录，保存了双方的卡牌和随机数种子，可以重新生成相同的对战过程
+type CardFightRecord struct {
+	gorm.Model
+	GroupId int64     `gorm:"index"`
+	UserIdA int64     `gorm:"index"`
+	UserIdB int64     `gorm:"index"`
+	CardA   FightCard `gorm:"embedded;embeddedPrefix:card_a_"`
+	CardB   FightCard `gorm:"embedded;embeddedPrefix:card_b_"`
+	Seed    int64
+	Outcome int
+	// 锦标赛中的对战记录所属的锦标赛和轮次
+	TournamentId uint `gorm:"index"`
+	Round        int
+}
+
+// Replay 使用保存的卡牌和随机数种子重新进行对战
+func (r CardFightRecord) Replay() cardfight.FightResult {
+	match := cardfight.FightMatch{
+		A:    newCardItem(r.CardA.ToCard(), r.UserIdA),
+		B:    newCardItem(r.CardB.ToCard(), r.UserIdB),
+		Seed: r.Seed,
+	}
+	return match.FightWithResult()
+}
@@ -0,0 +1,49 @@
+package table
+
+import (
+	"gorm.io/gorm"
+)
+
+const (
+	TournamentStatusRegistering = "registering"
+	TournamentStatusRunning     = "running"
+	TournamentStatusFinished    = "finished"
+	TournamentStatusCanceled    = "canceled"
+)
+
+// CardTournament 群内的卡牌对战锦标赛，报名结束后由定时任务逐轮进行淘汰赛
+type CardTournament struct {
+	gorm.Model
+	GroupId int64 `gorm:"index"`
+	// 创建锦标赛时使用的平台和机器人账号，用于发送每一轮的结果
+	Platform        string `gorm:"size:255"`
+	SelfId          int64
+	MessageTemplate int
+	CreatorId       int64
+	Status          string `gorm:"index;size:255"`
+	// 已经进行的轮次
+	Round      int
+	ChampionId int64
+}
+
+// CardTournamentEntry 锦标赛的报名记录，报名时选择的卡牌会保存下来用于之后的每一轮比赛
+type CardTournamentEntry struct {
+	gorm.Model
+	TournamentId uint `gorm:"index"`
+	UserId       int64
+	Card         FightCard `gorm:"embedded;embeddedPrefix:card_"`
+	// 被淘汰的轮次，为0时表示仍在比赛中
+	EliminatedRound int
+}
+
+// ToUserCard 报名时使用的卡牌，用于进行对战
+func (e CardTournamentEntry) ToUserCard() UserCard {
+	return UserCard{
+		UserId:     e.UserId,
+		Name:       e.Card.Name,
+		Class:      e.Card.Class,
+		Velocity:   e.Card.Velocity,
+		Firepower:  e.Card.Firepower,
+		Protection: e.Card.Protection,
+	}
+}
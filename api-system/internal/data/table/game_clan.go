@@ -0,0 +1,218 @@
+package table
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+const (
+	// ClanRankMinMember 库内收录的成员少于该值的联队不参与排行
+	ClanRankMinMember = 3
+	// clanStatMinMission 某一模式下任务数少于该值的成员不参与该模式胜率的统计
+	clanStatMinMission = RatingMinMission
+)
+
+// GameClan 战雷官网的联队资料
+type GameClan struct {
+	gorm.Model
+	// 联队标签，和玩家资料中的联队一致
+	Tag string `gorm:"uniqueIndex;size:255"`
+	// 联队全称
+	Name string `gorm:"size:255"`
+	// 联队页面地址
+	Url         string `gorm:"size:255"`
+	Description string
+	// 创建日期
+	RegisterDate time.Time
+	// 联队页面中的成员数
+	MemberCount int
+}
+
+// GameClanMember 联队页面中的成员列表
+type GameClanMember struct {
+	gorm.Model
+	Tag  string `gorm:"uniqueIndex:idx_game_clan_member_tag_nick;size:255"`
+	Nick string `gorm:"uniqueIndex:idx_game_clan_member_tag_nick;size:255"`
+	// 个人联队评分
+	Rating int
+	// 活跃度
+	Activity int
+	// 职位
+	Role string `gorm:"size:255"`
+	// 加入日期
+	JoinDate time.Time
+}
+
+// ClanStat 根据库内联队成员的玩家数据统计的联队数据
+type ClanStat struct {
+	Tag string
+	// 库内收录了数据的成员数
+	RecordedCount int
+	Level         RatingMetric
+	WinRateAb     RatingMetric
+	WinRateRb     RatingMetric
+	WinRateSb     RatingMetric
+	AsRateAb      RatingMetric
+	AsRateRb      RatingMetric
+	AsRateSb      RatingMetric
+}
+
+func addClanWinRate(m *RatingMetric, stat UserStat) {
+	if stat.TotalMission >= clanStatMinMission {
+		m.Add(stat.WinRate)
+	}
+}
+
+// addClanAsRate 只统计已经计算出效率值的成员
+func addClanAsRate(m *RatingMetric, rate float64) {
+	if rate > 0 {
+		m.Add(rate)
+	}
+}
+
+func (s *ClanStat) Add(u GameUser) {
+	s.RecordedCount++
+	s.Level.Add(float64(u.Level))
+	addClanWinRate(&s.WinRateAb, u.StatAb)
+	addClanWinRate(&s.WinRateRb, u.StatRb)
+	addClanWinRate(&s.WinRateSb, u.StatSb)
+	addClanAsRate(&s.AsRateAb, u.AsABRate)
+	addClanAsRate(&s.AsRateRb, u.AsRBRate)
+	addClanAsRate(&s.AsRateSb, u.AsSBRate)
+}
+
+// ClanStats 按联队标签分组统计玩家数据
+type ClanStats map[string]*ClanStat
+
+// Add 将玩家数据计入tag联队的统计
+func (s ClanStats) Add(tag string, u GameUser) {
+	if tag == "" {
+		return
+	}
+	stat, ok := s[tag]
+	if !ok {
+		stat = &ClanStat{Tag: tag}
+		s[tag] = stat
+	}
+	stat.Add(u)
+}
+
+// NewClanStats 以联队页面中的成员列表关联库内的玩家数据进行统计，没有收录数据的成员不计入
+func NewClanStats(members []GameClanMember, users []GameUser) ClanStats {
+	byNick := make(map[string]GameUser, len(users))
+	for _, u := range users {
+		byNick[u.Nick] = u
+	}
+	stats := make(ClanStats)
+	for _, m := range members {
+		if u, ok := byNick[m.Nick]; ok {
+			stats.Add(m.Tag, u)
+		}
+	}
+	return stats
+}
+
+// ClanRoster 联队的成员列表，按个人联队评分从高到低排序，并附上库内收录的玩家数据
+func ClanRoster(members []GameClanMember, users []GameUser) []display.ClanMember {
+	byNick := make(map[string]GameUser, len(users))
+	for _, u := range users {
+		byNick[u.Nick] = u
+	}
+	sorted := make([]GameClanMember, len(members))
+	copy(sorted, members)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Rating != sorted[j].Rating {
+			return sorted[i].Rating > sorted[j].Rating
+		}
+		return sorted[i].Nick < sorted[j].Nick
+	})
+	ret := make([]display.ClanMember, 0, len(sorted))
+	for _, m := range sorted {
+		item := display.ClanMember{
+			Nick:     m.Nick,
+			Role:     m.Role,
+			Rating:   m.Rating,
+			Activity: m.Activity,
+			JoinDate: m.JoinDate.Format("2006-01-02"),
+		}
+		if u, ok := byNick[m.Nick]; ok {
+			item.Recorded = true
+			item.Level = u.Level
+			item.WinRateRb = fmt.Sprintf("%.0f%%", u.StatRb.WinRate*100)
+		}
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+// Rank 按历史模式的平均胜率从高到低排序，收录成员不足的联队不参与排行
+func (s ClanStats) Rank(limit int) []ClanStat {
+	var ret []ClanStat
+	for _, stat := range s {
+		if stat.RecordedCount < ClanRankMinMember || stat.WinRateRb.Count == 0 {
+			continue
+		}
+		ret = append(ret, *stat)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].WinRateRb.Mean() != ret[j].WinRateRb.Mean() {
+			return ret[i].WinRateRb.Mean() > ret[j].WinRateRb.Mean()
+		}
+		if ret[i].RecordedCount != ret[j].RecordedCount {
+			return ret[i].RecordedCount > ret[j].RecordedCount
+		}
+		return ret[i].Tag < ret[j].Tag
+	})
+	if len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret
+}
+
+func formatClanWinRate(m RatingMetric) string {
+	if m.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", m.Mean()*100)
+}
+
+func formatClanAsRate(m RatingMetric) string {
+	if m.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", m.Mean())
+}
+
+func (c GameClan) ToDisplay(stat ClanStat, roster []display.ClanMember) display.GameClan {
+	zone := time.FixedZone("CST", 8*3600)
+	return display.GameClan{
+		Tag:           c.Tag,
+		Name:          c.Name,
+		Url:           c.Url,
+		Description:   c.Description,
+		RegisterDate:  c.RegisterDate.Format("2006-01-02"),
+		MemberCount:   c.MemberCount,
+		UpdatedAt:     c.UpdatedAt.In(zone).Format("2006-01-02 15:04:05"),
+		RecordedCount: stat.RecordedCount,
+		AvgLevel:      fmt.Sprintf("%.0f", stat.Level.Mean()),
+		WinRateAb:     formatClanWinRate(stat.WinRateAb),
+		WinRateRb:     formatClanWinRate(stat.WinRateRb),
+		WinRateSb:     formatClanWinRate(stat.WinRateSb),
+		AsRateAb:      formatClanAsRate(stat.AsRateAb),
+		AsRateRb:      formatClanAsRate(stat.AsRateRb),
+		AsRateSb:      formatClanAsRate(stat.AsRateSb),
+		Members:       roster,
+	}
+}
+
+func (s ClanStat) ToDisplayRankItem() display.ClanRankItem {
+	return display.ClanRankItem{
+		Tag:           s.Tag,
+		RecordedCount: s.RecordedCount,
+		WinRateRb:     formatClanWinRate(s.WinRateRb),
+		AsRateRb:      formatClanAsRate(s.AsRateRb),
+	}
+}
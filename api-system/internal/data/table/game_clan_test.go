@@ -0,0 +1,92 @@
+package table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func newClanUser(nick string, mission int, winRate float64) GameUser {
+	user := newRatingUser(mission, winRate, 50, 100)
+	user.Nick = nick
+	return user
+}
+
+func TestClanStatsRank(t *testing.T) {
+	var members []GameClanMember
+	var users []GameUser
+	for _, m := range []struct {
+		tag  string
+		user GameUser
+	}{
+		{tag: "-A-", user: newClanUser("a1", 100, 0.5)},
+		{tag: "-A-", user: newClanUser("a2", 100, 0.6)},
+		{tag: "-A-", user: newClanUser("a3", 100, 0.7)},
+		// 任务数不足的成员计入收录人数，但不参与胜率统计
+		{tag: "-A-", user: newClanUser("a4", RatingMinMission-1, 0)},
+		{tag: "-B-", user: newClanUser("b1", 100, 0.7)},
+		{tag: "-B-", user: newClanUser("b2", 100, 0.7)},
+		{tag: "-B-", user: newClanUser("b3", 100, 0.7)},
+		// 收录成员不足的联队不参与排行
+		{tag: "-C-", user: newClanUser("c1", 100, 0.9)},
+		{tag: "-C-", user: newClanUser("c2", 100, 0.9)},
+	} {
+		members = append(members, GameClanMember{Tag: m.tag, Nick: m.user.Nick})
+		users = append(users, m.user)
+	}
+	// 没有收录数据的成员不计入，不在成员列表中的玩家即使资料中的联队相同也不计入
+	members = append(members, GameClanMember{Tag: "-C-", Nick: "c3"})
+	outsider := newClanUser("outsider", 100, 1)
+	outsider.Clan = "-C-"
+	users = append(users, outsider)
+
+	stats := NewClanStats(members, users)
+	assert.Len(t, stats, 3)
+	assert.Equal(t, 4, stats["-A-"].RecordedCount)
+	assert.Equal(t, 3, stats["-A-"].WinRateRb.Count)
+
+	tests := []struct {
+		limit int
+		want  []string
+	}{
+		{limit: 10, want: []string{"-B-", "-A-"}},
+		{limit: 1, want: []string{"-B-"}},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var got []string
+			for _, stat := range stats.Rank(tt.limit) {
+				got = append(got, stat.Tag)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	item := stats["-A-"].ToDisplayRankItem()
+	assert.Equal(t, "60%", item.WinRateRb)
+	assert.Equal(t, "-", item.AsRateRb)
+	assert.Equal(t, 2, stats["-C-"].RecordedCount)
+}
+
+func TestClanRoster(t *testing.T) {
+	members := []GameClanMember{
+		{Tag: "-RB-", Nick: "Pebble", Rating: 1890, Role: "副指挥官"},
+		{Tag: "-RB-", Nick: "OnTheRocks", Rating: 2450, Role: "指挥官"},
+		{Tag: "-RB-", Nick: "Cheater42", Rating: 0, Role: "士兵"},
+		{Tag: "-RB-", Nick: "Boulder", Rating: 1890, Role: "军官"},
+	}
+	users := []GameUser{
+		newClanUser("OnTheRocks", 100, 0.55),
+		newClanUser("Unrelated", 100, 0.9),
+	}
+	roster := ClanRoster(members, users)
+	var nicks []string
+	for _, m := range roster {
+		nicks = append(nicks, m.Nick)
+	}
+	// 按联队评分从高到低排序，评分相同时按昵称排序
+	assert.Equal(t, []string{"OnTheRocks", "Boulder", "Pebble", "Cheater42"}, nicks)
+	assert.True(t, roster[0].Recorded)
+	assert.Equal(t, "55%", roster[0].WinRateRb)
+	assert.False(t, roster[1].Recorded)
+	assert.Empty(t, roster[1].WinRateRb)
+}
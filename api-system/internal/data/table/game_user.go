@@ -154,10 +154,6 @@ func (u GameUser) ToDisplayGameUser() display.GameUser {
 }
 
 func convertToStat(stat UserStat) display.UserStat {
-	var kd float64
-	if stat.DeadCount != 0 {
-		kd = float64(stat.GroundDestroyCount+stat.FleetDestroyCount+stat.AviationDestroyCount) / float64(stat.DeadCount)
-	}
 	return display.UserStat{
 		TotalMission:         stat.TotalMission,
 		WinRate:              fmt.Sprintf("%.0f%%", stat.WinRate*100),
@@ -168,12 +164,12 @@ func convertToStat(stat UserStat) display.UserStat {
 		WinCount:             stat.WinCount,
 		SliverEagleEarned:    stat.SliverEagleEarned,
 		DeadCount:            stat.DeadCount,
-		Kd:                   fmt.Sprintf("%.2f", kd),
+		Kd:                   fmt.Sprintf("%.2f", stat.Kd()),
 	}
 }
 
 func convertToAviationRate(rate AviationRate) display.AviationRate {
-	ka := float64(rate.TotalDestroyCount) / float64(rate.GameCount)
+	ka := rate.Ka()
 	return display.AviationRate{
 		Ka:                   fmt.Sprintf("%.2f", ka),
 		GameCount:            rate.GameCount,
@@ -192,7 +188,7 @@ func convertToAviationRate(rate AviationRate) display.AviationRate {
 }
 
 func convertToGroundRate(rate GroundRate) display.GroundRate {
-	ka := float64(rate.TotalDestroyCount) / float64(rate.GameCount)
+	ka := rate.Ka()
 	return display.GroundRate{
 		Ka:                     fmt.Sprintf("%.2f", ka),
 		GameCount:              rate.GameCount,
@@ -213,7 +209,7 @@ func convertToGroundRate(rate GroundRate) display.GroundRate {
 }
 
 func convertToFleetRate(rate FleetRate) display.FleetRate {
-	ka := float64(rate.TotalDestroyCount) / float64(rate.GameCount)
+	ka := rate.Ka()
 	return display.FleetRate{
 		Ka:                      fmt.Sprintf("%.2f", ka),
 		GameCount:               rate.GameCount,
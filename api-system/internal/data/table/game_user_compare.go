@@ -0,0 +1,90 @@
+package table
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+)
+
+func safeKa(destroyCount int, gameCount int) float64 {
+	if gameCount == 0 {
+		return 0
+	}
+	return float64(destroyCount) / float64(gameCount)
+}
+
+func (r AviationRate) Ka() float64 {
+	return safeKa(r.TotalDestroyCount, r.GameCount)
+}
+
+func (r GroundRate) Ka() float64 {
+	return safeKa(r.TotalDestroyCount, r.GameCount)
+}
+
+func (r FleetRate) Ka() float64 {
+	return safeKa(r.TotalDestroyCount, r.GameCount)
+}
+
+func newCompareRow(name string, a float64, b float64, format string) display.CompareRow {
+	row := display.CompareRow{
+		Name: name,
+		A:    fmt.Sprintf(format, a),
+		B:    fmt.Sprintf(format, b),
+	}
+	// 按展示的精度比较，避免显示相同却标记了一方更好
+	if row.A != row.B {
+		if a > b {
+			row.Better = display.CompareBetterA
+		} else {
+			row.Better = display.CompareBetterB
+		}
+	}
+	return row
+}
+
+func newStatCompareRows(mode string, a UserStat, b UserStat) []display.CompareRow {
+	return []display.CompareRow{
+		newCompareRow(mode+"任务数", float64(a.TotalMission), float64(b.TotalMission), "%.0f"),
+		newCompareRow(mode+"胜率", a.WinRate*100, b.WinRate*100, "%.0f%%"),
+		newCompareRow(mode+"KD", a.Kd(), b.Kd(), "%.2f"),
+	}
+}
+
+// CompareGameUser 逐项对比两个玩家的数据，每一项都是数值越大越好
+func CompareGameUser(a GameUser, b GameUser) display.ProfileCompare {
+	var stat []display.CompareRow
+	stat = append(stat, newStatCompareRows("街机", a.StatAb, b.StatAb)...)
+	stat = append(stat, newStatCompareRows("历史", a.StatRb, b.StatRb)...)
+	stat = append(stat, newStatCompareRows("全真", a.StatSb, b.StatSb)...)
+	return display.ProfileCompare{
+		NickA: a.Nick,
+		NickB: b.Nick,
+		Sections: []display.CompareSection{
+			{Title: "综合数据", Rows: stat},
+			{Title: "空战KA", Rows: []display.CompareRow{
+				newCompareRow("街机", a.AviationRateAb.Ka(), b.AviationRateAb.Ka(), "%.2f"),
+				newCompareRow("历史", a.AviationRateRb.Ka(), b.AviationRateRb.Ka(), "%.2f"),
+				newCompareRow("全真", a.AviationRateSb.Ka(), b.AviationRateSb.Ka(), "%.2f"),
+			}},
+			{Title: "陆战KA", Rows: []display.CompareRow{
+				newCompareRow("街机", a.GroundRateAb.Ka(), b.GroundRateAb.Ka(), "%.2f"),
+				newCompareRow("历史", a.GroundRateRb.Ka(), b.GroundRateRb.Ka(), "%.2f"),
+				newCompareRow("全真", a.GroundRateSb.Ka(), b.GroundRateSb.Ka(), "%.2f"),
+			}},
+			{Title: "海战KA", Rows: []display.CompareRow{
+				newCompareRow("街机", a.FleetRateAb.Ka(), b.FleetRateAb.Ka(), "%.2f"),
+				newCompareRow("历史", a.FleetRateRb.Ka(), b.FleetRateRb.Ka(), "%.2f"),
+				newCompareRow("全真", a.FleetRateSb.Ka(), b.FleetRateSb.Ka(), "%.2f"),
+			}},
+			{Title: "ThunderSkill效率", Rows: []display.CompareRow{
+				newCompareRow("街机", a.TsABRate, b.TsABRate, "%.0f%%"),
+				newCompareRow("历史", a.TsRBRate, b.TsRBRate, "%.0f%%"),
+				newCompareRow("全真", a.TsSBRate, b.TsSBRate, "%.0f%%"),
+			}},
+			{Title: "安东星效率", Rows: []display.CompareRow{
+				newCompareRow("街机", a.AsABRate, b.AsABRate, "%.1f%%"),
+				newCompareRow("历史", a.AsRBRate, b.AsRBRate, "%.1f%%"),
+				newCompareRow("全真", a.AsSBRate, b.AsSBRate, "%.1f%%"),
+			}},
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package table
+
+import (
+	"math"
+)
+
+const (
+	// RatingMinMission 某一模式下任务数少于该值的玩家不计算效率值，也不参与基准的统计
+	RatingMinMission = 30
+	// RatingMinPopulation 参与统计的玩家少于该值时基准不可信，不计算效率值
+	RatingMinPopulation = 20
+	// ratingMaxZScore 限制单项指标的标准分，避免个别极端数据左右整体评分
+	ratingMaxZScore = 3
+)
+
+// 各项指标在效率值中所占的权重
+const (
+	ratingWeightWinRate = 0.3
+	ratingWeightKd      = 0.3
+	ratingWeightKa      = 0.4
+)
+
+// RatingSample 计算效率值时用到的某一模式下的各项指标
+type RatingSample struct {
+	TotalMission      int
+	WinRate           float64
+	Kd                float64
+	AviationKa        float64
+	GroundKa          float64
+	FleetKa           float64
+	AviationGameCount int
+	GroundGameCount   int
+	FleetGameCount    int
+}
+
+func newRatingSample(stat UserStat, aviation AviationRate, ground GroundRate, fleet FleetRate) RatingSample {
+	return RatingSample{
+		TotalMission:      stat.TotalMission,
+		WinRate:           stat.WinRate,
+		Kd:                stat.Kd(),
+		AviationKa:        aviation.Ka(),
+		GroundKa:          ground.Ka(),
+		FleetKa:           fleet.Ka(),
+		AviationGameCount: aviation.GameCount,
+		GroundGameCount:   ground.GameCount,
+		FleetGameCount:    fleet.GameCount,
+	}
+}
+
+// RatingMetric 累计某一项指标在玩家群体中的分布
+type RatingMetric struct {
+	Count     int
+	Sum       float64
+	SumSquare float64
+}
+
+func (m *RatingMetric) Add(v float64) {
+	m.Count++
+	m.Sum += v
+	m.SumSquare += v * v
+}
+
+func (m RatingMetric) Mean() float64 {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.Sum / float64(m.Count)
+}
+
+func (m RatingMetric) Std() float64 {
+	if m.Count == 0 {
+		return 0
+	}
+	mean := m.Mean()
+	variance := m.SumSquare/float64(m.Count) - mean*mean
+	if variance <= 0 {
+		return 0
+	}
+	return math.Sqrt(variance)
+}
+
+// ZScore 数值相对于群体的标准分，群体没有差异时返回0
+func (m RatingMetric) ZScore(v float64) float64 {
+	std := m.Std()
+	if std == 0 {
+		return 0
+	}
+	z := (v - m.Mean()) / std
+	return math.Max(-ratingMaxZScore, math.Min(ratingMaxZScore, z))
+}
+
+// RatingBaseline 某一模式下用于归一化的群体基准
+type RatingBaseline struct {
+	Count      int
+	WinRate    RatingMetric
+	Kd         RatingMetric
+	AviationKa RatingMetric
+	GroundKa   RatingMetric
+	FleetKa    RatingMetric
+}
+
+func (b *RatingBaseline) Add(s RatingSample) {
+	if s.TotalMission < RatingMinMission {
+		return
+	}
+	b.Count++
+	b.WinRate.Add(s.WinRate)
+	b.Kd.Add(s.Kd)
+	// KA只统计玩过该类载具的玩家
+	if s.AviationGameCount > 0 {
+		b.AviationKa.Add(s.AviationKa)
+	}
+	if s.GroundGameCount > 0 {
+		b.GroundKa.Add(s.GroundKa)
+	}
+	if s.FleetGameCount > 0 {
+		b.FleetKa.Add(s.FleetKa)
+	}
+}
+
+// Rate 计算效率值，范围为0到100，表示玩家在库内玩家中大致的百分位。
+// 数据不足时返回0
+func (b RatingBaseline) Rate(s RatingSample) float64 {
+	if s.TotalMission < RatingMinMission || b.Count < RatingMinPopulation {
+		return 0
+	}
+	z := ratingWeightWinRate*b.WinRate.ZScore(s.WinRate) + ratingWeightKd*b.Kd.ZScore(s.Kd)
+	weight := ratingWeightWinRate + ratingWeightKd
+	// 各类载具的KA按出击数加权
+	totalGameCount := s.AviationGameCount + s.GroundGameCount + s.FleetGameCount
+	if totalGameCount > 0 {
+		ka := (float64(s.AviationGameCount)*b.AviationKa.ZScore(s.AviationKa) +
+			float64(s.GroundGameCount)*b.GroundKa.ZScore(s.GroundKa) +
+			float64(s.FleetGameCount)*b.FleetKa.ZScore(s.FleetKa)) / float64(totalGameCount)
+		z += ratingWeightKa * ka
+		weight += ratingWeightKa
+	}
+	z /= weight
+	percentile := 0.5 * (1 + math.Erf(z/math.Sqrt2))
+	return math.Round(percentile*1000) / 10
+}
+
+// GameUserRatingBaseline 街机、历史、全真三种模式的群体基准
+type GameUserRatingBaseline struct {
+	Ab RatingBaseline
+	Rb RatingBaseline
+	Sb RatingBaseline
+}
+
+func (u GameUser) ratingSamples() (RatingSample, RatingSample, RatingSample) {
+	return newRatingSample(u.StatAb, u.AviationRateAb, u.GroundRateAb, u.FleetRateAb),
+		newRatingSample(u.StatRb, u.AviationRateRb, u.GroundRateRb, u.FleetRateRb),
+		newRatingSample(u.StatSb, u.AviationRateSb, u.GroundRateSb, u.FleetRateSb)
+}
+
+func (b *GameUserRatingBaseline) Add(u GameUser) {
+	ab, rb, sb := u.ratingSamples()
+	b.Ab.Add(ab)
+	b.Rb.Add(rb)
+	b.Sb.Add(sb)
+}
+
+// Apply 计算玩家的安东星效率值并写入AsABRate、AsRBRate、AsSBRate
+func (b GameUserRatingBaseline) Apply(u *GameUser) {
+	ab, rb, sb := u.ratingSamples()
+	u.AsABRate = b.Ab.Rate(ab)
+	u.AsRBRate = b.Rb.Rate(rb)
+	u.AsSBRate = b.Sb.Rate(sb)
+}
@@ -0,0 +1,66 @@
+package table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func newRatingUser(mission int, winRate float64, destroy int, dead int) GameUser {
+	return GameUser{
+		StatRb: UserStat{
+			TotalMission:       mission,
+			WinRate:            winRate,
+			GroundDestroyCount: destroy,
+			DeadCount:          dead,
+		},
+		GroundRateRb: GroundRate{
+			GameCount:         mission,
+			TotalDestroyCount: destroy,
+		},
+	}
+}
+
+func TestGameUserRatingBaseline(t *testing.T) {
+	var baseline GameUserRatingBaseline
+	for i := 0; i < RatingMinPopulation; i++ {
+		baseline.Add(newRatingUser(100, 0.4+float64(i)*0.01, 50+i*5, 100))
+	}
+	// 任务数不足的玩家不参与统计
+	baseline.Add(newRatingUser(RatingMinMission-1, 1, 1000, 1))
+	assert.Equal(t, RatingMinPopulation, baseline.Rb.Count)
+	assert.Equal(t, 0, baseline.Ab.Count)
+
+	tests := []struct {
+		user GameUser
+		min  float64
+		max  float64
+	}{
+		{user: newRatingUser(100, 0.495, 97, 100), min: 45, max: 55},
+		{user: newRatingUser(100, 0.7, 300, 100), min: 90, max: 100},
+		{user: newRatingUser(100, 0.2, 10, 100), min: 0.1, max: 10},
+		{user: newRatingUser(RatingMinMission-1, 0.7, 300, 100), min: 0, max: 0},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			baseline.Apply(&tt.user)
+			assert.GreaterOrEqual(t, tt.user.AsRBRate, tt.min)
+			assert.LessOrEqual(t, tt.user.AsRBRate, tt.max)
+			// 其他模式没有足够的群体数据
+			assert.Equal(t, 0.0, tt.user.AsABRate)
+			assert.Equal(t, 0.0, tt.user.AsSBRate)
+		})
+	}
+}
+
+func TestRatingMetric(t *testing.T) {
+	var m RatingMetric
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		m.Add(v)
+	}
+	assert.InDelta(t, 5, m.Mean(), 1e-9)
+	assert.InDelta(t, 2, m.Std(), 1e-9)
+	assert.InDelta(t, 1, m.ZScore(7), 1e-9)
+	assert.InDelta(t, ratingMaxZScore, m.ZScore(100), 1e-9)
+	assert.Equal(t, 0.0, RatingMetric{}.ZScore(1))
+}
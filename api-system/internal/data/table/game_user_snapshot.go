@@ -0,0 +1,96 @@
+package table
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GameUserSnapshot 每次成功爬取玩家数据时保存一份快照，用于查看数据的变化趋势
+type GameUserSnapshot struct {
+	gorm.Model
+	Nick   string `gorm:"index;size:255"`
+	Level  int
+	StatAb UserStat `gorm:"embedded;embeddedPrefix:stat_ab_"`
+	StatRb UserStat `gorm:"embedded;embeddedPrefix:stat_rb_"`
+	StatSb UserStat `gorm:"embedded;embeddedPrefix:stat_sb_"`
+
+	TsABRate float64
+	TsRBRate float64
+	TsSBRate float64
+}
+
+func NewGameUserSnapshot(u GameUser) GameUserSnapshot {
+	return GameUserSnapshot{
+		Nick:     u.Nick,
+		Level:    u.Level,
+		StatAb:   u.StatAb,
+		StatRb:   u.StatRb,
+		StatSb:   u.StatSb,
+		TsABRate: u.TsABRate,
+		TsRBRate: u.TsRBRate,
+		TsSBRate: u.TsSBRate,
+	}
+}
+
+// SelectTrendSnapshots 从快照中选出计算变化趋势的基准和最新的快照。基准为since及之前最近的一份快照，
+// 没有时使用since之后最早的一份，不足两份不同的快照时返回false
+func SelectTrendSnapshots(snapshots []GameUserSnapshot, since time.Time) (GameUserSnapshot, GameUserSnapshot, bool) {
+	var before, after, last *GameUserSnapshot
+	for i := range snapshots {
+		s := &snapshots[i]
+		if !s.CreatedAt.After(since) {
+			if before == nil || s.CreatedAt.After(before.CreatedAt) {
+				before = s
+			}
+		} else if after == nil || s.CreatedAt.Before(after.CreatedAt) {
+			after = s
+		}
+		if last == nil || s.CreatedAt.After(last.CreatedAt) {
+			last = s
+		}
+	}
+	base := before
+	if base == nil {
+		base = after
+	}
+	if base == nil || base.ID == last.ID {
+		return GameUserSnapshot{}, GameUserSnapshot{}, false
+	}
+	return *base, *last, true
+}
+
+// Kd 击毁总数/阵亡数
+func (s UserStat) Kd() float64 {
+	if s.DeadCount == 0 {
+		return 0
+	}
+	return float64(s.GroundDestroyCount+s.FleetDestroyCount+s.AviationDestroyCount) / float64(s.DeadCount)
+}
+
+// ToDisplayTrend 计算从当前快照到later快照之间的数据变化
+func (s GameUserSnapshot) ToDisplayTrend(later GameUserSnapshot) display.ProfileTrend {
+	zone := time.FixedZone("CST", 8*3600)
+	return display.ProfileTrend{
+		Nick:       s.Nick,
+		From:       s.CreatedAt.In(zone).Format("2006-01-02 15:04"),
+		To:         later.CreatedAt.In(zone).Format("2006-01-02 15:04"),
+		Days:       int(later.CreatedAt.Sub(s.CreatedAt).Hours() / 24),
+		LevelDelta: later.Level - s.Level,
+		Ab:         convertToStatTrend(s.StatAb, later.StatAb),
+		Rb:         convertToStatTrend(s.StatRb, later.StatRb),
+		Sb:         convertToStatTrend(s.StatSb, later.StatSb),
+	}
+}
+
+func convertToStatTrend(from UserStat, to UserStat) display.StatTrend {
+	return display.StatTrend{
+		MissionDelta: to.TotalMission - from.TotalMission,
+		WinDelta:     to.WinCount - from.WinCount,
+		WinRateFrom:  fmt.Sprintf("%.0f%%", from.WinRate*100),
+		WinRateTo:    fmt.Sprintf("%.0f%%", to.WinRate*100),
+		KdFrom:       fmt.Sprintf("%.2f", from.Kd()),
+		KdTo:         fmt.Sprintf("%.2f", to.Kd()),
+	}
+}
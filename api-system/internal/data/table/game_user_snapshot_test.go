@@ -0,0 +1,54 @@
+package table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSelectTrendSnapshots(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	since := now.AddDate(0, 0, -7)
+	snapshot := func(id uint, t time.Time) GameUserSnapshot {
+		var s GameUserSnapshot
+		s.ID = id
+		s.CreatedAt = t
+		return s
+	}
+	eightDaysAgo := snapshot(1, now.AddDate(0, 0, -8))
+	tenDaysAgo := snapshot(2, now.AddDate(0, 0, -10))
+	atSince := snapshot(3, since)
+	threeDaysAgo := snapshot(4, now.AddDate(0, 0, -3))
+	today := snapshot(5, now)
+	tests := []struct {
+		snapshots []GameUserSnapshot
+		ok        bool
+		base      uint
+		last      uint
+	}{
+		// 区间之前只爬取过一次时以其为基准
+		{snapshots: []GameUserSnapshot{eightDaysAgo, today}, ok: true, base: 1, last: 5},
+		// 以区间之前最近的一份为基准
+		{snapshots: []GameUserSnapshot{today, tenDaysAgo, eightDaysAgo, threeDaysAgo}, ok: true, base: 1, last: 5},
+		{snapshots: []GameUserSnapshot{tenDaysAgo, atSince, today}, ok: true, base: 3, last: 5},
+		// 区间之前没有快照时以区间内最早的一份为基准
+		{snapshots: []GameUserSnapshot{today, threeDaysAgo}, ok: true, base: 4, last: 5},
+		// 区间内没有新的快照
+		{snapshots: []GameUserSnapshot{tenDaysAgo, eightDaysAgo}, ok: false},
+		{snapshots: []GameUserSnapshot{today}, ok: false},
+		{snapshots: []GameUserSnapshot{eightDaysAgo}, ok: false},
+		{snapshots: []GameUserSnapshot{today, today}, ok: false},
+		{snapshots: nil, ok: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			base, last, ok := SelectTrendSnapshots(tt.snapshots, since)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.base, base.ID)
+				assert.Equal(t, tt.last, last.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package table
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GameUserSource 玩家数据在每个来源最近一次获取的情况
+type GameUserSource struct {
+	gorm.Model
+	Nick   string `gorm:"uniqueIndex:idx_game_user_source_nick_source;size:255"`
+	Source string `gorm:"uniqueIndex:idx_game_user_source_nick_source;size:255"`
+	Found  bool
+	// 最近一次获取成功的时间
+	FetchedAt time.Time
+	// 最近一次获取失败的原因，获取成功后清空
+	LastError string
+}
+
+func (s GameUserSource) ToDisplay() display.GameUserSource {
+	return display.GameUserSource{
+		Source:    s.Source,
+		Found:     s.Found,
+		FetchedAt: s.FetchedAt,
+		LastError: s.LastError,
+	}
+}
@@ -0,0 +1,187 @@
+package table
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"sort"
+	"strings"
+)
+
+const (
+	LeaderboardMetricWinRate = "win_rate"
+	LeaderboardMetricKd      = "kd"
+	LeaderboardMetricMission = "mission"
+	LeaderboardMetricTsRate  = "ts_rate"
+)
+
+const (
+	GameModeAb = "ab"
+	GameModeRb = "rb"
+	GameModeSb = "sb"
+)
+
+var leaderboardMetricNames = map[string]string{
+	LeaderboardMetricWinRate: "胜率",
+	LeaderboardMetricKd:      "KD",
+	LeaderboardMetricMission: "任务数",
+	LeaderboardMetricTsRate:  "TS效率",
+}
+
+var gameModeNames = map[string]string{
+	GameModeAb: "街机",
+	GameModeRb: "历史",
+	GameModeSb: "全真",
+}
+
+// leaderboardMetricAlias 指令中可以使用的排行指标名称
+var leaderboardMetricAlias = map[string]string{
+	"胜率":   LeaderboardMetricWinRate,
+	"kd":   LeaderboardMetricKd,
+	"任务数":  LeaderboardMetricMission,
+	"场次":   LeaderboardMetricMission,
+	"效率":   LeaderboardMetricTsRate,
+	"ts":   LeaderboardMetricTsRate,
+	"ts效率": LeaderboardMetricTsRate,
+}
+
+// ParseLeaderboardMetric 解析排行指标，支持接口使用的英文标识和指令中的中文名称
+func ParseLeaderboardMetric(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if _, ok := leaderboardMetricNames[s]; ok {
+		return s, true
+	}
+	metric, ok := leaderboardMetricAlias[s]
+	return metric, ok
+}
+
+// ParseGameMode 解析游戏模式，支持英文标识和街机、历史、全真
+func ParseGameMode(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if _, ok := gameModeNames[s]; ok {
+		return s, true
+	}
+	for mode, name := range gameModeNames {
+		if name == s {
+			return mode, true
+		}
+	}
+	return "", false
+}
+
+func (u GameUser) modeStat(mode string) UserStat {
+	switch mode {
+	case GameModeAb:
+		return u.StatAb
+	case GameModeSb:
+		return u.StatSb
+	default:
+		return u.StatRb
+	}
+}
+
+func (u GameUser) modeTsRate(mode string) float64 {
+	switch mode {
+	case GameModeAb:
+		return u.TsABRate
+	case GameModeSb:
+		return u.TsSBRate
+	default:
+		return u.TsRBRate
+	}
+}
+
+// leaderboardValue 玩家在排行指标下的数值，数据不足时不参与排行
+func leaderboardValue(u GameUser, metric string, mode string) (float64, bool) {
+	stat := u.modeStat(mode)
+	switch metric {
+	case LeaderboardMetricWinRate:
+		return stat.WinRate, stat.TotalMission >= RatingMinMission
+	case LeaderboardMetricKd:
+		return stat.Kd(), stat.TotalMission >= RatingMinMission
+	case LeaderboardMetricMission:
+		return float64(stat.TotalMission), stat.TotalMission > 0
+	case LeaderboardMetricTsRate:
+		rate := u.modeTsRate(mode)
+		return rate, rate > 0
+	default:
+		return 0, false
+	}
+}
+
+func formatLeaderboardValue(metric string, v float64) string {
+	switch metric {
+	case LeaderboardMetricWinRate:
+		return fmt.Sprintf("%.0f%%", v*100)
+	case LeaderboardMetricKd:
+		return fmt.Sprintf("%.2f", v)
+	case LeaderboardMetricTsRate:
+		return fmt.Sprintf("%.0f%%", v)
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
+
+type leaderboardEntry struct {
+	Nick  string
+	Value float64
+}
+
+// Leaderboard 按指定模式和指标对玩家排行
+type Leaderboard struct {
+	Metric  string
+	Mode    string
+	entries []leaderboardEntry
+	nicks   map[string]bool
+}
+
+func NewLeaderboard(metric string, mode string) *Leaderboard {
+	return &Leaderboard{
+		Metric: metric,
+		Mode:   mode,
+		nicks:  make(map[string]bool),
+	}
+}
+
+// Add 加入一名玩家，多个成员绑定了同一个游戏昵称时只计算一次
+func (l *Leaderboard) Add(u GameUser) {
+	if l.nicks[u.Nick] {
+		return
+	}
+	value, ok := leaderboardValue(u, l.Metric, l.Mode)
+	if !ok {
+		return
+	}
+	l.nicks[u.Nick] = true
+	l.entries = append(l.entries, leaderboardEntry{Nick: u.Nick, Value: value})
+}
+
+// Len 参与排行的玩家数
+func (l *Leaderboard) Len() int {
+	return len(l.entries)
+}
+
+// ToDisplay 从高到低排序后取出offset开始的limit名玩家
+func (l *Leaderboard) ToDisplay(offset int, limit int) display.Leaderboard {
+	sort.SliceStable(l.entries, func(i, j int) bool {
+		if l.entries[i].Value != l.entries[j].Value {
+			return l.entries[i].Value > l.entries[j].Value
+		}
+		return l.entries[i].Nick < l.entries[j].Nick
+	})
+	ret := display.Leaderboard{
+		Metric:     l.Metric,
+		MetricName: leaderboardMetricNames[l.Metric],
+		Mode:       l.Mode,
+		ModeName:   gameModeNames[l.Mode],
+		Total:      len(l.entries),
+		Items:      []display.LeaderboardItem{},
+	}
+	for i := offset; i < len(l.entries) && i < offset+limit; i++ {
+		ret.Items = append(ret.Items, display.LeaderboardItem{
+			Rank:  i + 1,
+			Nick:  l.entries[i].Nick,
+			Value: formatLeaderboardValue(l.Metric, l.entries[i].Value),
+		})
+	}
+	return ret
+}
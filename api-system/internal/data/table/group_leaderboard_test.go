@@ -0,0 +1,79 @@
+package table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestParseLeaderboardArgs(t *testing.T) {
+	tests := []struct {
+		metric     string
+		wantMetric string
+		mode       string
+		wantMode   string
+		ok         bool
+	}{
+		{metric: "胜率", wantMetric: LeaderboardMetricWinRate, mode: "历史", wantMode: GameModeRb, ok: true},
+		{metric: "KD", wantMetric: LeaderboardMetricKd, mode: "街机", wantMode: GameModeAb, ok: true},
+		{metric: "场次", wantMetric: LeaderboardMetricMission, mode: "SB", wantMode: GameModeSb, ok: true},
+		{metric: "ts_rate", wantMetric: LeaderboardMetricTsRate, mode: "rb", wantMode: GameModeRb, ok: true},
+		{metric: "击杀", mode: "历史", wantMode: GameModeRb, ok: false},
+		{metric: "胜率", wantMetric: LeaderboardMetricWinRate, mode: "娱乐", ok: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			metric, metricOk := ParseLeaderboardMetric(tt.metric)
+			mode, modeOk := ParseGameMode(tt.mode)
+			assert.Equal(t, tt.ok, metricOk && modeOk)
+			assert.Equal(t, tt.wantMetric, metric)
+			assert.Equal(t, tt.wantMode, mode)
+		})
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	users := []GameUser{
+		newRatingUser(100, 0.5, 100, 100),
+		newRatingUser(200, 0.6, 50, 100),
+		newRatingUser(300, 0.6, 300, 100),
+		// 任务数不足时不参与胜率和KD排行
+		newRatingUser(RatingMinMission-1, 0.9, 100, 1),
+		{},
+	}
+	for i := range users {
+		users[i].Nick = "nick" + strconv.Itoa(i)
+		users[i].TsRBRate = float64(i * 10)
+	}
+	tests := []struct {
+		metric string
+		offset int
+		limit  int
+		total  int
+		want   []string
+	}{
+		{metric: LeaderboardMetricWinRate, limit: 10, total: 3, want: []string{"nick1 60%", "nick2 60%", "nick0 50%"}},
+		{metric: LeaderboardMetricKd, limit: 2, total: 3, want: []string{"nick2 3.00", "nick0 1.00"}},
+		{metric: LeaderboardMetricMission, offset: 1, limit: 2, total: 4, want: []string{"nick1 200", "nick0 100"}},
+		{metric: LeaderboardMetricTsRate, offset: 3, limit: 10, total: 4, want: []string{"nick1 10%"}},
+		{metric: LeaderboardMetricTsRate, offset: 10, limit: 10, total: 4, want: nil},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			leaderboard := NewLeaderboard(tt.metric, GameModeRb)
+			for _, u := range users {
+				leaderboard.Add(u)
+			}
+			// 重复的昵称只计算一次
+			leaderboard.Add(users[0])
+			ret := leaderboard.ToDisplay(tt.offset, tt.limit)
+			assert.Equal(t, tt.total, ret.Total)
+			var got []string
+			for j, item := range ret.Items {
+				assert.Equal(t, tt.offset+j+1, item.Rank)
+				got = append(got, item.Nick+" "+item.Value)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
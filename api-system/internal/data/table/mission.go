@@ -8,6 +8,7 @@ import (
 const (
 	MissionTypeWTProfile = "profile"
 	MissionTypeUserInfo  = "userinfo"
+	MissionTypeClan      = "clan"
 )
 
 const (
@@ -27,4 +28,14 @@ type Mission struct {
 	Process      float64
 	Detail       string
 	Result       string
+	// 已经执行的次数
+	Attempts int
+	// 下一次可以执行的时间，失败后会延迟一段时间再重试
+	NextRunAt time.Time `gorm:"index"`
+	// 正在执行任务的worker以及租约的过期时间，租约过期的任务会被重新领取
+	LeaseOwner string `gorm:"size:255"`
+	LeaseUntil time.Time
+	LastError  string
+	// 去重的key，相同key的任务同时只会有一个在执行
+	DedupKey string `gorm:"size:255"`
 }
@@ -0,0 +1,66 @@
+package table
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+// PlayerSubscription 用户在群内订阅自己绑定的玩家，定时刷新数据并在资料变化时通知到群
+type PlayerSubscription struct {
+	gorm.Model
+	UserId  int64 `gorm:"uniqueIndex:idx_player_subscription_user_group"`
+	GroupId int64 `gorm:"uniqueIndex:idx_player_subscription_user_group"`
+	// 订阅时使用的平台和机器人账号，用于发送通知
+	Platform        string `gorm:"size:255"`
+	SelfId          int64
+	MessageTemplate int
+	// 上次检查时玩家的资料，用于发现变化
+	Nick   string `gorm:"size:255"`
+	Level  int
+	Title  string `gorm:"size:255"`
+	Clan   string `gorm:"size:255"`
+	Banned bool
+	// 上次检查的时间，定时任务按该时间轮流刷新
+	CheckedAt time.Time `gorm:"index"`
+}
+
+// Diff 对比玩家当前的资料和上次检查时的资料。昵称不同时是刚订阅或换绑了玩家，不产生变化
+func (s PlayerSubscription) Diff(u GameUser) []display.PlayerChange {
+	if s.Nick != u.Nick {
+		return nil
+	}
+	var changes []display.PlayerChange
+	if u.Level != s.Level {
+		changes = append(changes, display.PlayerChange{Nick: u.Nick, Item: "等级", From: strconv.Itoa(s.Level), To: strconv.Itoa(u.Level)})
+	}
+	if u.Title != s.Title {
+		changes = append(changes, display.PlayerChange{Nick: u.Nick, Item: "称号", From: s.Title, To: u.Title})
+	}
+	if u.Clan != s.Clan {
+		changes = append(changes, display.PlayerChange{Nick: u.Nick, Item: "联队", From: s.Clan, To: u.Clan})
+	}
+	banned := u.Banned != nil && *u.Banned
+	if banned != s.Banned {
+		changes = append(changes, display.PlayerChange{Nick: u.Nick, Item: "封禁状态", From: bannedText(s.Banned), To: bannedText(banned)})
+	}
+	return changes
+}
+
+func bannedText(banned bool) string {
+	if banned {
+		return "已封禁"
+	}
+	return "正常"
+}
+
+// Track 记录玩家当前的资料作为下次对比的基准
+func (s *PlayerSubscription) Track(u GameUser, now time.Time) {
+	s.Nick = u.Nick
+	s.Level = u.Level
+	s.Title = u.Title
+	s.Clan = u.Clan
+	s.Banned = u.Banned != nil && *u.Banned
+	s.CheckedAt = now
+}
@@ -0,0 +1,49 @@
+package table
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPlayerSubscriptionDiff(t *testing.T) {
+	yes, no := true, false
+	base := GameUser{Nick: "OnTheRocks", Level: 99, Title: "坦克杀手", Clan: "-RB-", Banned: &no}
+	tests := []struct {
+		update func(u *GameUser)
+		want   []display.PlayerChange
+	}{
+		{update: func(u *GameUser) {}, want: nil},
+		{update: func(u *GameUser) {
+			u.Level = 100
+			u.Title = "空战王牌"
+		}, want: []display.PlayerChange{
+			{Nick: "OnTheRocks", Item: "等级", From: "99", To: "100"},
+			{Nick: "OnTheRocks", Item: "称号", From: "坦克杀手", To: "空战王牌"},
+		}},
+		{update: func(u *GameUser) { u.Clan = "" }, want: []display.PlayerChange{
+			{Nick: "OnTheRocks", Item: "联队", From: "-RB-", To: ""},
+		}},
+		{update: func(u *GameUser) { u.Banned = &yes }, want: []display.PlayerChange{
+			{Nick: "OnTheRocks", Item: "封禁状态", From: "正常", To: "已封禁"},
+		}},
+		// 换绑了其他玩家时只更新基准
+		{update: func(u *GameUser) {
+			u.Nick = "Cheater42"
+			u.Banned = &yes
+		}, want: nil},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var sub PlayerSubscription
+			sub.Track(base, time.Now())
+			user := base
+			tt.update(&user)
+			assert.Equal(t, tt.want, sub.Diff(user))
+			sub.Track(user, time.Now())
+			assert.Nil(t, sub.Diff(user))
+		})
+	}
+}
@@ -38,6 +38,7 @@ func DefaultGroupConfig(groupId int64) QQGroupConfig {
 		EnableActionLuck:    &trueVal,
 		EnableActionSetting: &falseVal,
 		EnableCheckBiliRoom: &falseVal,
+		EnableCheckWTNew:    &falseVal,
 		TodayQueryCount:     0,
 		OneDayQueryLimit:    50,
 		TotalQueryCount:     0,
@@ -58,6 +59,7 @@ func (c QQGroupConfig) ToDisplay() display.QQGroupConfig {
 		EnableActionLuck:    *c.EnableActionLuck,
 		EnableActionSetting: *c.EnableActionSetting,
 		EnableCheckBiliRoom: *c.EnableCheckBiliRoom,
+		EnableCheckWTNew:    c.EnableCheckWTNew != nil && *c.EnableCheckWTNew, // 早期创建的群配置没有该字段
 		MessageTemplate:     c.MessageTemplate,
 	}
 }
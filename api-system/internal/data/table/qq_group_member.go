@@ -0,0 +1,15 @@
+package table
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// QQGroupMember 在群内发过言的成员，由群消息事件记录，用于统计群内绑定了游戏昵称的成员
+type QQGroupMember struct {
+	gorm.Model
+	GroupId int64 `gorm:"uniqueIndex:idx_qq_group_member_group_user"`
+	UserId  int64 `gorm:"uniqueIndex:idx_qq_group_member_group_user"`
+	// 最近一次记录到该成员发言的时间
+	LastSeenAt time.Time
+}
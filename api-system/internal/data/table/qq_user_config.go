@@ -15,6 +15,8 @@ type QQUserConfig struct {
 	Admin            *bool
 	SuperAdmin       *bool
 	BindingGameNick  *string
+	// 绑定的游戏昵称是否通过了验证，早期的绑定没有经过验证
+	BindingVerified *bool
 }
 
 func DefaultUserConfig(userId int64) QQUserConfig {
@@ -32,5 +34,6 @@ func DefaultUserConfig(userId int64) QQUserConfig {
 		Admin:            &falseVal,
 		SuperAdmin:       &falseVal,
 		BindingGameNick:  nil,
+		BindingVerified:  &falseVal,
 	}
 }
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+)
+
+func SaveAuditLog(log table.AuditLog) error {
+	if err := dal.AuditLog.Save(&log); err != nil {
+		return err
+	}
+	return nil
+}
+
+func MustSaveAuditLog(log table.AuditLog) {
+	if err := SaveAuditLog(log); err != nil {
+		logging.L().Error("dal failed", logging.Error(err))
+	}
+}
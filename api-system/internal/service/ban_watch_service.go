@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	// banWatchMaxPerGroup 每个群最多关注的玩家数
+	banWatchMaxPerGroup = 50
+	// banWatchSnapshotLookup 查找最后已知数据时最多回溯的快照数
+	banWatchSnapshotLookup = 10
+)
+
+// ErrBanWatchLimit 群内关注的玩家已达到上限
+var ErrBanWatchLimit = errors.New("ban watch limit reached")
+
+func FindBanWatches(groupId int64) ([]*table.BanWatch, error) {
+	bw := dal.BanWatch
+	return bw.Where(bw.GroupId.Eq(groupId)).Order(bw.CreatedAt).Find()
+}
+
+// FindBanWatchList 群内关注的玩家以及最近一次检查的封禁状态
+func FindBanWatchList(groupId int64) (*display.BanWatchList, error) {
+	watches, err := FindBanWatches(groupId)
+	if err != nil {
+		return nil, err
+	}
+	ret := display.BanWatchList{
+		Max:     banWatchMaxPerGroup,
+		Watches: []display.BanWatch{},
+	}
+	for _, watch := range watches {
+		ret.Watches = append(ret.Watches, watch.ToDisplay())
+	}
+	return &ret, nil
+}
+
+// FindBanEvents 按时间倒序分页查找群内关注的玩家的封禁状态变化记录
+func FindBanEvents(groupId int64, offset int, limit int) ([]display.BanEvent, int64, error) {
+	be := dal.BanEvent
+	events, total, err := be.Where(be.GroupId.Eq(groupId)).Order(be.CreatedAt.Desc()).FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	ret := []display.BanEvent{}
+	for _, event := range events {
+		ret = append(ret, event.ToDisplay())
+	}
+	return ret, total, nil
+}
+
+// AddBanWatch 在群内关注玩家，已关注时更新发送通知使用的账号和消息模板
+func AddBanWatch(sendForm bot.Reply, nick string) error {
+	bw := dal.BanWatch
+	watch, err := bw.Where(bw.GroupId.Eq(sendForm.GroupId), bw.Nick.Eq(nick)).Take()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		count, err := bw.Where(bw.GroupId.Eq(sendForm.GroupId)).Count()
+		if err != nil {
+			return err
+		}
+		if count >= banWatchMaxPerGroup {
+			return ErrBanWatchLimit
+		}
+		watch = &table.BanWatch{
+			GroupId:   sendForm.GroupId,
+			Nick:      nick,
+			CreatorId: sendForm.UserId,
+		}
+		// 库内已有数据时以当前的封禁状态作为基准
+		if user, err := FindGameProfile(nick); err == nil {
+			watch.Check(*user, table.GameUserSnapshot{}, time.Now())
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+	watch.Platform = sendForm.Platform
+	watch.SelfId = sendForm.SelfId
+	watch.MessageTemplate = sendForm.MessageTemplate
+	return bw.Save(watch)
+}
+
+// RemoveBanWatch 取消关注，返回是否存在关注
+func RemoveBanWatch(groupId int64, nick string) (bool, error) {
+	bw := dal.BanWatch
+	info, err := bw.Unscoped().Where(bw.GroupId.Eq(groupId), bw.Nick.Eq(nick)).Delete()
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// MustRemoveBanWatches 机器人退群后删除该群关注的所有玩家
+func MustRemoveBanWatches(groupId int64) {
+	bw := dal.BanWatch
+	if _, err := bw.Unscoped().Where(bw.GroupId.Eq(groupId)).Delete(); err != nil {
+		logging.L().Error("dal failed", logging.Error(err))
+	}
+}
+
+// findLastKnownStat 玩家最近一次有战绩的快照，被封禁的玩家在官网上不再显示战绩
+func findLastKnownStat(nick string) table.GameUserSnapshot {
+	gus := dal.GameUserSnapshot
+	snapshots, err := gus.Where(gus.Nick.Eq(nick)).Order(gus.CreatedAt.Desc()).Limit(banWatchSnapshotLookup).Find()
+	if err != nil {
+		logging.L().Warn("find game user snapshot failed", logging.Error(err))
+		return table.GameUserSnapshot{}
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.StatAb.TotalMission+snapshot.StatRb.TotalMission+snapshot.StatSb.TotalMission > 0 {
+			return *snapshot
+		}
+	}
+	return table.GameUserSnapshot{}
+}
+
+// checkBanWatches 对比刷新后的玩家资料，封禁状态变化时记录并通知关注的群。
+// 多个群关注同一个玩家时一起检查，stats为刷新前最后已知的数据
+func checkBanWatches(nicks []string, deadline time.Time, stats map[string]table.GameUserSnapshot, now time.Time) error {
+	bw := dal.BanWatch
+	watches, err := bw.Where(bw.Nick.In(nicks...), bw.CheckedAt.Lt(deadline)).Find()
+	if err != nil {
+		return err
+	}
+	stopResponse := IsStopAllResponse()
+	for _, watch := range watches {
+		user, err := FindGameProfile(watch.Nick)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				logging.L().Warn("find game profile failed", logging.Error(err))
+				continue
+			}
+			// 没有取得玩家资料时无法判断封禁状态，保留上次的状态，只更新处理时间等待下次检查
+			if err := bw.Save(watch); err != nil {
+				logging.L().Error("dal failed", logging.Error(err))
+			}
+			continue
+		}
+		event := watch.Check(*user, stats[watch.Nick], now)
+		if err := bw.Save(watch); err != nil {
+			logging.L().Error("dal failed", logging.Error(err))
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		if err := dal.BanEvent.Create(event); err != nil {
+			logging.L().Error("dal failed", logging.Error(err))
+		}
+		if stopResponse {
+			continue
+		}
+		bot.MustSend(bot.Reply{
+			Platform:        watch.Platform,
+			SelfId:          watch.SelfId,
+			MessageType:     bot.MessageTypeGroup,
+			GroupId:         watch.GroupId,
+			Message:         event.ToDisplay().ToFriendlyString(),
+			MessageTemplate: watch.MessageTemplate,
+		})
+	}
+	return nil
+}
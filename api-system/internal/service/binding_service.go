@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/cache"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+	"time"
+)
+
+// bindingChallengeExpire 绑定验证的有效期
+const bindingChallengeExpire = time.Minute * 30
+
+const (
+	bindingStateVerified   = "已验证"
+	bindingStateUnverified = "未验证"
+)
+
+// ErrNoBindingChallenge 用户没有正在进行的绑定验证，或者验证已过期
+var ErrNoBindingChallenge = errors.New("no binding challenge")
+
+// BindingChallenge 绑定验证。官网资料中玩家能够自己修改的只有称号，而称号只能从游戏内已获得的称号中选择，
+// 无法写入验证码，因此记录开始验证时刚刷新的称号，用户在游戏内换成其他称号来证明账号归属
+type BindingChallenge struct {
+	Nick  string `json:"nick"`
+	Title string `json:"title"`
+}
+
+// IsPassedBy 刷新后的资料中称号已经换成其他称号时验证通过
+func (c BindingChallenge) IsPassedBy(user table.GameUser) bool {
+	return user.Nick == c.Nick && user.Title != c.Title
+}
+
+// StartBindingChallenge 以刚刷新的游戏资料为基准开始绑定验证，覆盖之前未完成的验证
+func StartBindingChallenge(userId int64, user table.GameUser) (*BindingChallenge, error) {
+	challenge := BindingChallenge{
+		Nick:  user.Nick,
+		Title: user.Title,
+	}
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return nil, err
+	}
+	key := cache.GenerateBindingVerifyCacheKey(userId)
+	if err := cache.Client().Set(context.Background(), key, data, bindingChallengeExpire).Err(); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func FindBindingChallenge(userId int64) (*BindingChallenge, error) {
+	key := cache.GenerateBindingVerifyCacheKey(userId)
+	result, err := cache.Client().Get(context.Background(), key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrNoBindingChallenge
+		}
+		return nil, err
+	}
+	var challenge BindingChallenge
+	if err := json.Unmarshal([]byte(result), &challenge); err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func MustDeleteBindingChallenge(userId int64) {
+	key := cache.GenerateBindingVerifyCacheKey(userId)
+	if err := cache.Client().Del(context.Background(), key).Err(); err != nil {
+		logging.L().Error("delete cache error", logging.Error(err))
+	}
+}
+
+// ConfirmBinding 验证通过后绑定游戏昵称，同时解除其他用户对该昵称的绑定
+func ConfirmBinding(userId int64, nick string) error {
+	trueVal := true
+	return dal.Q.Transaction(func(tx *dal.Query) error {
+		uc := tx.QQUserConfig
+		if _, err := uc.Where(uc.BindingGameNick.Eq(nick), uc.UserId.Neq(userId)).
+			Updates(map[string]any{"binding_game_nick": nil, "binding_verified": false}); err != nil {
+			return err
+		}
+		config, err := uc.Where(uc.UserId.Eq(userId)).Take()
+		if err != nil {
+			return err
+		}
+		config.BindingGameNick = &nick
+		config.BindingVerified = &trueVal
+		return uc.Save(config)
+	})
+}
+
+// FindBindingState 游戏昵称的绑定状态，有用户通过验证时为已验证，只有早期未验证的绑定时为未验证，没有绑定时为空
+func FindBindingState(nick string) (string, error) {
+	uc := dal.QQUserConfig
+	configs, err := uc.Where(uc.BindingGameNick.Eq(nick)).Find()
+	if err != nil {
+		return "", err
+	}
+	if len(configs) == 0 {
+		return "", nil
+	}
+	for _, config := range configs {
+		if config.BindingVerified != nil && *config.BindingVerified {
+			return bindingStateVerified, nil
+		}
+	}
+	return bindingStateUnverified, nil
+}
+
+// toDisplayGameUser 转换为展示用的玩家数据，并附带绑定状态
+func toDisplayGameUser(user table.GameUser) display.GameUser {
+	ret := user.ToDisplayGameUser()
+	state, err := FindBindingState(user.Nick)
+	if err != nil {
+		logging.L().Warn("find binding state failed", logging.Error(err))
+	}
+	ret.BindingState = state
+	return ret
+}
+
+// WaitForBindingStarted 等待游戏资料刷新后记录当前的称号，并提示用户换成其他称号
+func WaitForBindingStarted(missionId string, nick string, sendForm bot.Reply) {
+	resp := bot.SelectStaticMessage(sendForm.MessageTemplate).CommonResp
+	if !WaitForMissionsFinished([]string{missionId}) {
+		sendForm.Message = "对不起，查询超时，请稍后重试"
+	} else if mission, err := FindMission(missionId); err != nil || mission.Status == table.MissionStatusFailed {
+		sendForm.Message = resp.BindingError
+	} else if user, err := FindGameProfile(nick); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("find game profile failed", logging.Error(err))
+		}
+		sendForm.Message = resp.BindingNickNotExist
+	} else if challenge, err := StartBindingChallenge(sendForm.UserId, *user); err != nil {
+		logging.L().Warn("start binding challenge failed", logging.Error(err))
+		sendForm.Message = resp.BindingError
+	} else {
+		sendForm.Message = fmt.Sprintf(resp.BindingVerifyCode,
+			challenge.Nick, challenge.Title, int(bindingChallengeExpire.Minutes()))
+	}
+	bot.MustSend(sendForm)
+}
+
+// WaitForBindingVerified 等待游戏资料刷新后核对称号，验证通过时完成绑定
+func WaitForBindingVerified(missionId string, challenge BindingChallenge, sendForm bot.Reply) {
+	resp := bot.SelectStaticMessage(sendForm.MessageTemplate).CommonResp
+	if !WaitForMissionsFinished([]string{missionId}) {
+		sendForm.Message = "对不起，查询超时，请稍后重试"
+	} else if mission, err := FindMission(missionId); err != nil || mission.Status == table.MissionStatusFailed {
+		sendForm.Message = resp.BindingVerifyFailed
+	} else if user, err := FindGameProfile(challenge.Nick); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("find game profile failed", logging.Error(err))
+		}
+		sendForm.Message = resp.BindingNickNotExist
+	} else if !challenge.IsPassedBy(*user) {
+		sendForm.Message = fmt.Sprintf(resp.BindingVerifyMismatch, challenge.Nick, challenge.Title)
+	} else if err := ConfirmBinding(sendForm.UserId, user.Nick); err != nil {
+		logging.L().Warn("confirm binding failed", logging.Error(err))
+		sendForm.Message = resp.BindingError
+	} else {
+		MustDeleteBindingChallenge(sendForm.UserId)
+		sendForm.Message = resp.BindingSuccess
+	}
+	bot.MustSend(sendForm)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestBindingChallengeIsPassedBy(t *testing.T) {
+	challenge := BindingChallenge{Nick: "OnTheRocks", Title: "坦克杀手"}
+	tests := []struct {
+		user table.GameUser
+		want bool
+	}{
+		{user: table.GameUser{Nick: "OnTheRocks", Title: "坦克杀手"}, want: false},
+		{user: table.GameUser{Nick: "OnTheRocks", Title: "王牌飞行员"}, want: true},
+		// 取消称号同样算作换成其他称号
+		{user: table.GameUser{Nick: "OnTheRocks", Title: ""}, want: true},
+		{user: table.GameUser{Nick: "Pebble", Title: "王牌飞行员"}, want: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, challenge.IsPassedBy(tt.user))
+		})
+	}
+	// 开始验证时没有称号的玩家需要设置一个称号
+	assert.False(t, BindingChallenge{Nick: "Pebble"}.IsPassedBy(table.GameUser{Nick: "Pebble"}))
+	assert.True(t, BindingChallenge{Nick: "Pebble"}.IsPassedBy(table.GameUser{Nick: "Pebble", Title: "坦克杀手"}))
+}
@@ -6,16 +6,19 @@ import (
 	"github.com/axiangcoding/antonstar-bot/internal/data/display"
 	"github.com/axiangcoding/antonstar-bot/internal/data/table"
 	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
 	"github.com/axiangcoding/antonstar-bot/pkg/cqhttp"
-	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
+	"github.com/axiangcoding/antonstar-bot/pkg/kook"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
-	"github.com/google/uuid"
 	"github.com/panjf2000/ants/v2"
 	"golang.org/x/exp/rand"
+	"golang.org/x/exp/slices"
 	"gorm.io/gorm"
 	"hash/crc32"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // DrawNumber 抽一个数字
@@ -47,7 +50,7 @@ func NumberBasedResponse(number int32, template int) string {
 }
 
 // QueryWTGamerProfile 查询系统中已有的玩家的游戏资料。如果资料不存在，则调用爬虫爬取
-func QueryWTGamerProfile(nickname string, sendForm cqhttp.SendGroupMsgForm) (*string, *display.GameUser, error) {
+func QueryWTGamerProfile(nickname string, sendForm bot.Reply) (*string, *display.GameUser, error) {
 	find, err := FindGameProfile(nickname)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil, err
@@ -58,79 +61,55 @@ func QueryWTGamerProfile(nickname string, sendForm cqhttp.SendGroupMsgForm) (*st
 			return missionId, nil, nil
 		}
 	} else {
-		user := find.ToDisplayGameUser()
+		user := toDisplayGameUser(*find)
 		return nil, &user, nil
 	}
 }
 
-// RefreshWTUserInfo 刷新游戏数据
-func RefreshWTUserInfo(nickname string, sendForm cqhttp.SendGroupMsgForm) (*string, error) {
-	missionId := uuid.NewString()
+// RefreshWTUserInfo 提交刷新游戏数据的任务，任务由worker执行。
+// 同一个玩家正在爬取时不再重复提交，直接返回正在执行的任务id
+func RefreshWTUserInfo(nickname string, sendForm bot.Reply) (*string, error) {
 	form := ScheduleForm{
 		SendForm: sendForm,
 		Nick:     nickname,
 	}
-	if err := SubmitMissionWithDetail(missionId, table.MissionTypeUserInfo, form); err != nil {
+	missionId, submitted, err := SubmitDedupMission(table.MissionTypeUserInfo, nickname, form)
+	if err != nil {
 		return nil, err
 	}
-	if err := ants.Submit(func() {
-		if err := crawler.GetProfileFromWTOfficial(nickname,
-			func(status int, user *table.GameUser) {
-				switch status {
-				case crawler.StatusQueryFailed:
-					MustFinishMissionWithResult(missionId, table.MissionStatusFailed, CrawlerResult{
-						Found: false,
-						Nick:  nickname,
-					})
-				case crawler.StatusNotFound:
-					MustPutRefreshFlag(nickname)
-					MustFinishMissionWithResult(missionId, table.MissionStatusSuccess, CrawlerResult{
-						Found: false,
-						Nick:  nickname,
-					})
-				case crawler.StatusFound:
-					// live, psn等用户的昵称在html中会被cf认为是邮箱而隐藏，这里需要覆盖爬取来的数据
-					user.Nick = nickname
-					_, err := FindGameProfile(nickname)
-					if err != nil {
-						if errors.Is(err, gorm.ErrRecordNotFound) {
-							MustSaveGameProfile(user)
-						} else {
-							logging.L().Warn("find game profile failed", logging.Error(err))
-						}
-					} else {
-						MustUpdateGameProfile(nickname, user)
-					}
-
-					if err := crawler.GetProfileFromThunderskill(nickname, func(status int, skill *crawler.ThunderSkillResp) {
-						skillData := skill.Stats
-						data, err := FindGameProfile(nickname)
-						data.TsSBRate = skillData.S.Kpd
-						data.TsRBRate = skillData.R.Kpd
-						data.TsABRate = skillData.A.Kpd
-						if err == nil {
-							MustUpdateGameProfile(nickname, data)
-						}
-					}); err != nil {
-						logging.L().Warn("failed on update thunder skill profile. ", logging.Error(err))
-					}
-					MustPutRefreshFlag(nickname)
-					MustFinishMissionWithResult(missionId, table.MissionStatusSuccess, CrawlerResult{
-						Found: true,
-						Nick:  nickname,
-						Data:  *user},
-					)
-				}
-			}); err != nil {
-			logging.L().Warn("start crawler failed. ", logging.Error(err))
-		}
-	}); err != nil {
-		return nil, err
+	if !submitted {
+		logging.L().Info("attach to running mission",
+			logging.Any("nick", nickname),
+			logging.Any("missionId", missionId))
 	}
 	return &missionId, nil
 }
 
-func DoActionQuery(retMsgForm *cqhttp.SendGroupMsgForm, value string, fullMsg bool) {
+// reachQueryLimit 检查群和qq的查询限制，达到限制时写入提示消息
+func reachQueryLimit(retMsgForm *bot.Reply) bool {
+	if !retMsgForm.IsPrivate() {
+		if limit, usage, total := CheckGroupTodayQueryLimit(retMsgForm.GroupId); limit {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayGroupQueryLimit, usage, total)
+			return true
+		}
+	}
+	if limit, usage, total := CheckUserTodayQueryLimit(retMsgForm.UserId); limit {
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayUserQueryLimit, usage, total)
+		return true
+	}
+	return false
+}
+
+func mustAddQueryCount(retMsgForm *bot.Reply) {
+	MustAddUserConfigTodayQueryCount(retMsgForm.UserId, 1)
+	MustAddUserConfigTotalQueryCount(retMsgForm.UserId, 1)
+	if !retMsgForm.IsPrivate() {
+		MustAddGroupConfigTodayQueryCount(retMsgForm.GroupId, 1)
+		MustAddGroupConfigTotalQueryCount(retMsgForm.GroupId, 1)
+	}
+}
+
+func DoActionQuery(retMsgForm *bot.Reply, value string, fullMsg bool) {
 	if IsStopGlobalQuery() {
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.StopGlobalQuery
 		return
@@ -150,14 +129,7 @@ func DoActionQuery(retMsgForm *cqhttp.SendGroupMsgForm, value string, fullMsg bo
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.NotValidNickname
 		return
 	}
-	// 检查群查询限制
-	if limit, usage, total := CheckGroupTodayQueryLimit(retMsgForm.GroupId); limit {
-		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayGroupQueryLimit, usage, total)
-		return
-	}
-	// 检查qq查询限制
-	if limit, usage, total := CheckUserTodayQueryLimit(retMsgForm.UserId); limit {
-		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayUserQueryLimit, usage, total)
+	if reachQueryLimit(retMsgForm) {
 		return
 	}
 	mId, user, err := QueryWTGamerProfile(value, *retMsgForm)
@@ -167,8 +139,9 @@ func DoActionQuery(retMsgForm *cqhttp.SendGroupMsgForm, value string, fullMsg bo
 	}
 	if mId != nil {
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.QueryIsRunning
+		sendForm := *retMsgForm
 		if err := ants.Submit(func() {
-			if err := WaitForCrawlerFinished(*mId, fullMsg); err != nil {
+			if err := WaitForCrawlerFinished(*mId, sendForm, fullMsg); err != nil {
 				logging.L().Error("wait for callback error", logging.Error(err))
 			}
 		}); err != nil {
@@ -181,13 +154,10 @@ func DoActionQuery(retMsgForm *cqhttp.SendGroupMsgForm, value string, fullMsg bo
 			retMsgForm.Message = user.ToFriendlyShortString()
 		}
 	}
-	MustAddUserConfigTodayQueryCount(retMsgForm.UserId, 1)
-	MustAddUserConfigTotalQueryCount(retMsgForm.UserId, 1)
-	MustAddGroupConfigTodayQueryCount(retMsgForm.GroupId, 1)
-	MustAddGroupConfigTotalQueryCount(retMsgForm.GroupId, 1)
+	mustAddQueryCount(retMsgForm)
 }
 
-func DoActionRefresh(retMsgForm *cqhttp.SendGroupMsgForm, value string) {
+func DoActionRefresh(retMsgForm *bot.Reply, value string) {
 	if IsStopGlobalQuery() {
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.StopGlobalQuery
 		return
@@ -207,50 +177,642 @@ func DoActionRefresh(retMsgForm *cqhttp.SendGroupMsgForm, value string) {
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TooShortToRefresh
 		return
 	}
-	// 检查群查询限制
-	if limit, usage, total := CheckGroupTodayQueryLimit(retMsgForm.GroupId); limit {
-		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayGroupQueryLimit, usage, total)
-		return
-	}
-	// 检查qq查询限制
-	if limit, usage, total := CheckUserTodayQueryLimit(retMsgForm.UserId); limit {
-		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayUserQueryLimit, usage, total)
+	if reachQueryLimit(retMsgForm) {
 		return
 	}
 	missionId, err := RefreshWTUserInfo(value, *retMsgForm)
 	if err != nil {
 		logging.L().Warn("refresh WT gamer profile error", logging.Error(err))
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.CanNotRefresh
+		return
 	}
 	retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.QueryIsRunning
+	sendForm := *retMsgForm
 	if err := ants.Submit(func() {
-		if err := WaitForCrawlerFinished(*missionId, false); err != nil {
+		if err := WaitForCrawlerFinished(*missionId, sendForm, false); err != nil {
 			logging.L().Error("wait for callback error", logging.Error(err))
 		}
 	}); err != nil {
 		logging.L().Error("submit ant job failed", logging.Error(err))
 	}
-	MustAddUserConfigTodayQueryCount(retMsgForm.UserId, 1)
-	MustAddUserConfigTotalQueryCount(retMsgForm.UserId, 1)
-	MustAddGroupConfigTodayQueryCount(retMsgForm.GroupId, 1)
-	MustAddGroupConfigTotalQueryCount(retMsgForm.GroupId, 1)
+	mustAddQueryCount(retMsgForm)
+}
+
+// resolveBindingNick 将“我”替换为用户绑定的游戏昵称，未绑定时写入提示消息并返回false
+func resolveBindingNick(retMsgForm *bot.Reply, nick string) (string, bool) {
+	if nick != "我" {
+		return nick, true
+	}
+	config := MustFindUserConfig(retMsgForm.UserId)
+	if config != nil && config.BindingGameNick != nil && *config.BindingGameNick != "" {
+		return *config.BindingGameNick, true
+	}
+	retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.BindingFirst
+	return "", false
+}
+
+// DoActionCompare 对比两个玩家的数据，缺少数据的玩家会先进行爬取，完成后再发送对比结果
+func DoActionCompare(retMsgForm *bot.Reply, value string) {
+	if IsStopGlobalQuery() {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.StopGlobalQuery
+		return
+	}
+	split := strings.Fields(value)
+	if len(split) != 2 {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.CompareUsage
+		return
+	}
+	var nicks []string
+	for _, item := range split {
+		nick, ok := resolveBindingNick(retMsgForm, item)
+		if !ok {
+			return
+		}
+		if !IsValidNickname(nick) {
+			retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.NotValidNickname
+			return
+		}
+		nicks = append(nicks, nick)
+	}
+	if reachQueryLimit(retMsgForm) {
+		return
+	}
+	var missionIds []string
+	for _, nick := range nicks {
+		if _, err := FindGameProfile(nick); err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		// 刚爬取过仍然没有数据的玩家不再重复爬取
+		if !CanBeRefresh(nick) {
+			continue
+		}
+		missionId, err := RefreshWTUserInfo(nick, *retMsgForm)
+		if err != nil {
+			logging.L().Warn("refresh WT gamer profile error", logging.Error(err))
+			continue
+		}
+		missionIds = append(missionIds, *missionId)
+	}
+	if len(missionIds) > 0 {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.QueryIsRunning
+		sendForm := *retMsgForm
+		if err := ants.Submit(func() {
+			WaitForCompareFinished(missionIds, nicks[0], nicks[1], sendForm)
+		}); err != nil {
+			logging.L().Error("submit ant job failed", logging.Error(err))
+		}
+	} else {
+		fillCompareMessage(retMsgForm, nicks[0], nicks[1])
+	}
+	mustAddQueryCount(retMsgForm)
+}
+
+func fillCompareMessage(retMsgForm *bot.Reply, nickA string, nickB string) {
+	compare, missing, err := CompareGameProfiles(nickA, nickB)
+	if err != nil {
+		logging.L().Warn("compare game profiles failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.CompareFailed
+		return
+	}
+	if len(missing) > 0 {
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.CompareNotFound, strings.Join(missing, "、"))
+		return
+	}
+	retMsgForm.Message = compare.ToFriendlyString()
+}
+
+// clanTagMaxLength 联队标签的最大长度
+const clanTagMaxLength = 16
+
+// DoActionClan 查看联队资料以及库内成员数据的统计，资料过期时先刷新。值为“我”时查看绑定玩家所在的联队
+func DoActionClan(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	if IsStopGlobalQuery() {
+		retMsgForm.Message = resp.StopGlobalQuery
+		return
+	}
+	tag := strings.TrimSpace(value)
+	if tag == "我" {
+		nick, ok := resolveBindingNick(retMsgForm, tag)
+		if !ok {
+			return
+		}
+		user, err := FindGameProfile(nick)
+		if err != nil || user.Clan == "" {
+			retMsgForm.Message = resp.ClanUsage
+			return
+		}
+		tag = user.Clan
+	}
+	if tag == "" || strings.ContainsAny(tag, " \t") || utf8.RuneCountInString(tag) > clanTagMaxLength {
+		retMsgForm.Message = resp.ClanUsage
+		return
+	}
+	if !CanClanBeRefresh(tag) {
+		fillClanMessage(retMsgForm, tag)
+		return
+	}
+	if reachQueryLimit(retMsgForm) {
+		return
+	}
+	missionId, err := RefreshGameClan(tag, *retMsgForm)
+	if err != nil {
+		if errors.Is(err, ErrClanUnknown) {
+			retMsgForm.Message = fmt.Sprintf(resp.ClanNotFound, tag)
+			return
+		}
+		// 无法刷新时使用已有的数据
+		logging.L().Warn("refresh game clan failed", logging.Error(err))
+		fillClanMessage(retMsgForm, tag)
+		return
+	}
+	retMsgForm.Message = resp.QueryIsRunning
+	sendForm := *retMsgForm
+	if err := ants.Submit(func() {
+		WaitForClanFinished(*missionId, tag, sendForm)
+	}); err != nil {
+		logging.L().Error("submit ant job failed", logging.Error(err))
+	}
+	mustAddQueryCount(retMsgForm)
+}
+
+// DoActionClanRank 按成员的历史平均胜率查看库内的联队排行
+func DoActionClanRank(retMsgForm *bot.Reply) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	rank, err := FindClanRank()
+	if err != nil {
+		logging.L().Warn("find clan rank failed", logging.Error(err))
+		retMsgForm.Message = resp.ClanFailed
+		return
+	}
+	if len(rank.Items) == 0 {
+		retMsgForm.Message = resp.ClanRankEmpty
+		return
+	}
+	retMsgForm.Message = rank.ToFriendlyString()
+}
+
+// DoActionLeaderboard 查看本群绑定了游戏昵称的成员的排行，值为“指标 模式”，缺省时为历史胜率
+func DoActionLeaderboard(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	metric, mode := table.LeaderboardMetricWinRate, table.GameModeRb
+	split := strings.Fields(value)
+	if len(split) > 2 {
+		retMsgForm.Message = resp.LeaderboardUsage
+		return
+	}
+	if len(split) > 0 {
+		var ok bool
+		if metric, ok = table.ParseLeaderboardMetric(split[0]); !ok {
+			retMsgForm.Message = resp.LeaderboardUsage
+			return
+		}
+	}
+	if len(split) > 1 {
+		var ok bool
+		if mode, ok = table.ParseGameMode(split[1]); !ok {
+			retMsgForm.Message = resp.LeaderboardUsage
+			return
+		}
+	}
+	leaderboard, err := FindGroupLeaderboard(retMsgForm.GroupId, metric, mode, 0, groupLeaderboardSize)
+	if err != nil {
+		logging.L().Warn("find group leaderboard failed", logging.Error(err))
+		retMsgForm.Message = resp.LeaderboardFailed
+		return
+	}
+	if leaderboard.Total == 0 {
+		retMsgForm.Message = resp.LeaderboardEmpty
+		return
+	}
+	retMsgForm.Message = leaderboard.ToFriendlyString()
+}
+
+// DoActionSubscribe 在本群订阅用户绑定的玩家，资料变化时通知到群
+func DoActionSubscribe(retMsgForm *bot.Reply) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	nick, ok := resolveBindingNick(retMsgForm, "我")
+	if !ok {
+		return
+	}
+	if err := SubscribePlayer(*retMsgForm, nick); err != nil {
+		logging.L().Warn("subscribe player failed", logging.Error(err))
+		retMsgForm.Message = resp.SubscribeFailed
+		return
+	}
+	retMsgForm.Message = fmt.Sprintf(resp.SubscribeSuccess, nick)
+}
+
+func DoActionUnsubscribe(retMsgForm *bot.Reply) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	exist, err := UnsubscribePlayer(retMsgForm.UserId, retMsgForm.GroupId)
+	if err != nil {
+		logging.L().Warn("unsubscribe player failed", logging.Error(err))
+		retMsgForm.Message = resp.SubscribeFailed
+		return
+	}
+	if !exist {
+		retMsgForm.Message = resp.UnsubscribeNotExist
+		return
+	}
+	retMsgForm.Message = resp.UnsubscribeSuccess
 }
 
-func DoActionDrawCard(retMsgForm *cqhttp.SendGroupMsgForm, value string, id int64) {
-	retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.DrawCard
+// DoActionBanWatch 在本群关注玩家，封禁状态变化时通知到群。不带昵称时查看本群关注的玩家
+func DoActionBanWatch(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	nick := strings.TrimSpace(value)
+	if nick == "" {
+		list, err := FindBanWatchList(retMsgForm.GroupId)
+		if err != nil {
+			logging.L().Warn("find ban watch list failed", logging.Error(err))
+			retMsgForm.Message = resp.BanWatchFailed
+			return
+		}
+		if len(list.Watches) == 0 {
+			retMsgForm.Message = resp.BanWatchListEmpty
+			return
+		}
+		retMsgForm.Message = list.ToFriendlyString()
+		return
+	}
+	if !IsValidNickname(nick) {
+		retMsgForm.Message = resp.NotValidNickname
+		return
+	}
+	if err := AddBanWatch(*retMsgForm, nick); err != nil {
+		if errors.Is(err, ErrBanWatchLimit) {
+			retMsgForm.Message = fmt.Sprintf(resp.BanWatchLimit, banWatchMaxPerGroup)
+			return
+		}
+		logging.L().Warn("add ban watch failed", logging.Error(err))
+		retMsgForm.Message = resp.BanWatchFailed
+		return
+	}
+	retMsgForm.Message = fmt.Sprintf(resp.BanWatchSuccess, nick)
 }
 
-func DoActionLuck(retMsgForm *cqhttp.SendGroupMsgForm, value string, id int64) {
+func DoActionBanUnwatch(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	nick := strings.TrimSpace(value)
+	if !IsValidNickname(nick) {
+		retMsgForm.Message = resp.NotValidNickname
+		return
+	}
+	exist, err := RemoveBanWatch(retMsgForm.GroupId, nick)
+	if err != nil {
+		logging.L().Warn("remove ban watch failed", logging.Error(err))
+		retMsgForm.Message = resp.BanWatchFailed
+		return
+	}
+	if !exist {
+		retMsgForm.Message = fmt.Sprintf(resp.BanUnwatchNotExist, nick)
+		return
+	}
+	retMsgForm.Message = fmt.Sprintf(resp.BanUnwatchSuccess, nick)
+}
+
+// DoActionTrend 查看玩家最近一段时间的数据变化，格式为 昵称 [天数]
+func DoActionTrend(retMsgForm *bot.Reply, value string) {
+	days := 7
+	split := strings.Fields(value)
+	if len(split) == 2 {
+		if d, err := strconv.Atoi(split[1]); err == nil && d > 0 {
+			days = d
+		}
+	}
+	var nick string
+	if len(split) > 0 {
+		nick = split[0]
+	}
+	nick, ok := resolveBindingNick(retMsgForm, nick)
+	if !ok {
+		return
+	}
+	if !IsValidNickname(nick) {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.NotValidNickname
+		return
+	}
+	trend, err := FindProfileTrend(nick, days)
+	if err != nil {
+		if !errors.Is(err, ErrNotEnoughSnapshot) {
+			logging.L().Warn("find profile trend failed", logging.Error(err))
+		}
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TrendNotEnough, nick, days)
+		return
+	}
+	retMsgForm.Message = trend.ToFriendlyString()
+}
+
+func DoActionDrawCard(retMsgForm *bot.Reply, value string, id int64) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	card, err := DrawUserCard(id)
+	if err != nil {
+		if errors.Is(err, ErrCardDrawLimit) {
+			retMsgForm.Message = fmt.Sprintf(resp.DrawCardLimit, cardDrawDailyLimit)
+			return
+		}
+		logging.L().Warn("draw card failed", logging.Error(err))
+		retMsgForm.Message = resp.CardFailed
+		return
+	}
+	cards, err := FindUserCards(id)
+	if err != nil {
+		logging.L().Warn("find user cards failed", logging.Error(err))
+	}
+	retMsgForm.Message = fmt.Sprintf(resp.DrawCard, card.ToDisplay().ToFriendlyString(), len(cards))
+}
+
+// DoActionCardList 查看自己的卡牌
+func DoActionCardList(retMsgForm *bot.Reply) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	cards, err := FindUserCards(retMsgForm.UserId)
+	if err != nil {
+		logging.L().Warn("find user cards failed", logging.Error(err))
+		retMsgForm.Message = resp.CardFailed
+		return
+	}
+	if len(cards) == 0 {
+		retMsgForm.Message = resp.CardNoCard
+		return
+	}
+	var userCards display.UserCards
+	for _, card := range cards {
+		userCards.Cards = append(userCards.Cards, card.ToDisplay())
+	}
+	retMsgForm.Message = userCards.ToFriendlyString()
+}
+
+// DoActionCardFight 使用自己的卡牌挑战群友，value为 @群友 [卡牌名称]，不指定卡牌时随机选择
+func DoActionCardFight(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	args := strings.Fields(value)
+	if len(args) == 0 {
+		retMsgForm.Message = resp.CardFightUsage
+		return
+	}
+	enemyId, ok := parseManageTarget(retMsgForm.Platform, args[0])
+	if !ok {
+		retMsgForm.Message = resp.CardFightUsage
+		return
+	}
+	if enemyId == retMsgForm.UserId {
+		retMsgForm.Message = resp.CardFightSelf
+		return
+	}
+	cardName := strings.Join(args[1:], " ")
+	myCard, err := PickUserCard(retMsgForm.UserId, cardName)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("pick user card failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		} else if cardName != "" {
+			retMsgForm.Message = fmt.Sprintf(resp.CardNotFound, cardName)
+		} else {
+			retMsgForm.Message = resp.CardNoCard
+		}
+		return
+	}
+	enemyCard, err := PickUserCard(enemyId, "")
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("pick user card failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		} else {
+			retMsgForm.Message = resp.CardFightEnemyNoCard
+		}
+		return
+	}
+	record, result, err := CardFight(retMsgForm.GroupId, myCard, enemyCard)
+	if err != nil {
+		logging.L().Warn("card fight failed", logging.Error(err))
+		retMsgForm.Message = resp.CardFailed
+		return
+	}
+	fillCardFightMessage(retMsgForm, record, result)
+}
+
+// DoActionCardReplay 根据对战编号重新生成群内的一场对战
+func DoActionCardReplay(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		retMsgForm.Message = resp.CardFightReplayUsage
+		return
+	}
+	record, err := FindCardFightRecord(uint(id))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logging.L().Warn("find card fight record failed", logging.Error(err))
+		retMsgForm.Message = resp.CardFailed
+		return
+	}
+	// 只能回放本群的对战
+	if err != nil || record.GroupId != retMsgForm.GroupId {
+		retMsgForm.Message = fmt.Sprintf(resp.CardFightReplayNotFound, id)
+		return
+	}
+	result := record.Replay()
+	fillCardFightMessage(retMsgForm, record, &result)
+}
+
+func fillCardFightMessage(retMsgForm *bot.Reply, record *table.CardFightRecord, result *cardfight.FightResult) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	var resultText string
+	switch result.Outcome {
+	case cardfight.FightResultAWin:
+		resultText = fmt.Sprintf(resp.CardFightWin, bot.Mention(retMsgForm.Platform, record.UserIdA))
+	case cardfight.FightResultBWin:
+		resultText = fmt.Sprintf(resp.CardFightWin, bot.Mention(retMsgForm.Platform, record.UserIdB))
+	default:
+		resultText = resp.CardFightDraw
+	}
+	retMsgForm.Message = "\n" + cardfight.GenerateFightText(result.Steps()) + resultText +
+		fmt.Sprintf(resp.CardFightReplayHint, record.ID, record.ID)
+}
+
+// DoActionCardTeamFight 使用自己的多张卡牌与群友进行团战，value为 @群友
+func DoActionCardTeamFight(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	enemyId, ok := parseManageTarget(retMsgForm.Platform, strings.TrimSpace(value))
+	if !ok {
+		retMsgForm.Message = resp.CardTeamFightUsage
+		return
+	}
+	if enemyId == retMsgForm.UserId {
+		retMsgForm.Message = resp.CardFightSelf
+		return
+	}
+	myCards, err := PickUserCards(retMsgForm.UserId, cardTeamSize)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("pick user cards failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		} else {
+			retMsgForm.Message = resp.CardNoCard
+		}
+		return
+	}
+	enemyCards, err := PickUserCards(enemyId, cardTeamSize)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("pick user cards failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		} else {
+			retMsgForm.Message = resp.CardFightEnemyNoCard
+		}
+		return
+	}
+	result := CardTeamFight(retMsgForm.GroupId, myCards, enemyCards)
+	var resultText string
+	switch result.Outcome {
+	case cardfight.FightResultAWin:
+		resultText = fmt.Sprintf(resp.CardFightWin, bot.Mention(retMsgForm.Platform, retMsgForm.UserId))
+	case cardfight.FightResultBWin:
+		resultText = fmt.Sprintf(resp.CardFightWin, bot.Mention(retMsgForm.Platform, enemyId))
+	default:
+		resultText = resp.CardFightDraw
+	}
+	retMsgForm.Message = "\n" + cardfight.GenerateFightText(result.Steps()) + resultText
+}
+
+// DoActionTournament 卡牌锦标赛，创建、开始和取消需要群主、群管理员或者超级管理员
+func DoActionTournament(retMsgForm *bot.Reply, uc *table.QQUserConfig, role string, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	subCmd, arg, _ := strings.Cut(strings.TrimSpace(value), " ")
+	arg = strings.TrimSpace(arg)
+	isManager := role == bot.RoleOwner || role == bot.RoleAdmin || (uc.SuperAdmin != nil && *uc.SuperAdmin)
+
+	if subCmd == "创建" {
+		if !isManager {
+			retMsgForm.Message = resp.CardTournamentNotPermit
+			return
+		}
+		tournament, err := CreateCardTournament(retMsgForm)
+		if err != nil {
+			if errors.Is(err, ErrTournamentExist) {
+				retMsgForm.Message = resp.CardTournamentExist
+			} else {
+				logging.L().Warn("create card tournament failed", logging.Error(err))
+				retMsgForm.Message = resp.CardFailed
+			}
+			return
+		}
+		retMsgForm.Message = fmt.Sprintf(resp.CardTournamentCreated, tournament.ID)
+		return
+	}
+	if subCmd != "报名" && subCmd != "开始" && subCmd != "取消" && subCmd != "状态" {
+		retMsgForm.Message = resp.CardTournamentUsage
+		return
+	}
+
+	tournament, err := FindActiveCardTournament(retMsgForm.GroupId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			retMsgForm.Message = resp.CardTournamentNotFound
+		} else {
+			logging.L().Warn("find card tournament failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		}
+		return
+	}
+	switch subCmd {
+	case "报名":
+		card, err := PickUserCard(retMsgForm.UserId, arg)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				logging.L().Warn("pick user card failed", logging.Error(err))
+				retMsgForm.Message = resp.CardFailed
+			} else if arg != "" {
+				retMsgForm.Message = fmt.Sprintf(resp.CardNotFound, arg)
+			} else {
+				retMsgForm.Message = resp.CardNoCard
+			}
+			return
+		}
+		err = JoinCardTournament(tournament, card)
+		switch {
+		case err == nil:
+			retMsgForm.Message = fmt.Sprintf(resp.CardTournamentJoined, card.Name)
+		case errors.Is(err, ErrTournamentNotRegistering):
+			retMsgForm.Message = resp.CardTournamentNotRegistering
+		case errors.Is(err, ErrTournamentEntryExist):
+			retMsgForm.Message = resp.CardTournamentAlreadyJoined
+		default:
+			logging.L().Warn("join card tournament failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		}
+	case "开始":
+		if !isManager {
+			retMsgForm.Message = resp.CardTournamentNotPermit
+			return
+		}
+		err = StartCardTournament(tournament)
+		switch {
+		case err == nil:
+			retMsgForm.Message = resp.CardTournamentStarted
+		case errors.Is(err, ErrTournamentNotRegistering):
+			retMsgForm.Message = resp.CardTournamentNotRegistering
+		case errors.Is(err, ErrTournamentNotEnoughEntry):
+			retMsgForm.Message = fmt.Sprintf(resp.CardTournamentNotEnough, cardTournamentMinEntries)
+		default:
+			logging.L().Warn("start card tournament failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+		}
+	case "取消":
+		if !isManager {
+			retMsgForm.Message = resp.CardTournamentNotPermit
+			return
+		}
+		if err := CancelCardTournament(tournament); err != nil {
+			logging.L().Warn("cancel card tournament failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+			return
+		}
+		retMsgForm.Message = resp.CardTournamentCanceled
+	case "状态":
+		t, err := CardTournamentToDisplay(tournament, retMsgForm.Platform)
+		if err != nil {
+			logging.L().Warn("find card tournament entries failed", logging.Error(err))
+			retMsgForm.Message = resp.CardFailed
+			return
+		}
+		retMsgForm.Message = t.ToFriendlyString()
+	}
+}
+
+// DoActionCardRank 查看群内的卡牌对战排行
+func DoActionCardRank(retMsgForm *bot.Reply) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	stats, err := FindCardFightRank(retMsgForm.GroupId)
+	if err != nil {
+		logging.L().Warn("find card fight rank failed", logging.Error(err))
+		retMsgForm.Message = resp.CardFailed
+		return
+	}
+	if len(stats) == 0 {
+		retMsgForm.Message = resp.CardFightRankEmpty
+		return
+	}
+	var rank display.CardFightRank
+	for i, stat := range stats {
+		item := stat.ToDisplay()
+		item.Rank = i + 1
+		rank.Stats = append(rank.Stats, item)
+	}
+	retMsgForm.Message = rank.ToFriendlyString()
+}
+
+func DoActionLuck(retMsgForm *bot.Reply, value string, id int64) {
 	number := DrawNumber(id, time.Now().In(time.FixedZone("CST", 8*3600)))
 	retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Luck, number, NumberBasedResponse(number, retMsgForm.MessageTemplate))
 }
 
-func DoActionGroupStatus(retMsgForm *cqhttp.SendGroupMsgForm) {
+func DoActionGroupStatus(retMsgForm *bot.Reply) {
 	config := MustFindGroupConfig(retMsgForm.GroupId)
 	retMsgForm.Message = config.ToDisplay().ToFriendlyString()
 }
 
-func DoActionData(retMsgForm *cqhttp.SendGroupMsgForm, value string) {
+func DoActionData(retMsgForm *bot.Reply, value string) {
 	botQueryPrefix := ".cqbot 数据 "
 	retMsgForm.MessagePrefix = ""
 	opt1 := "导弹数据"
@@ -264,34 +826,95 @@ func DoActionData(retMsgForm *cqhttp.SendGroupMsgForm, value string) {
 	}
 }
 
-func DoActionBinding(retMsgForm *cqhttp.SendGroupMsgForm, value string) {
+// DoActionBinding 刷新待绑定玩家的游戏资料并记录当前的称号，用户在游戏内换成其他称号后发送验证指令完成绑定
+func DoActionBinding(retMsgForm *bot.Reply, value string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	if IsStopGlobalQuery() {
+		retMsgForm.Message = resp.StopGlobalQuery
+		return
+	}
+	value = strings.TrimSpace(value)
+	if !IsValidNickname(value) {
+		retMsgForm.Message = resp.NotValidNickname
+		return
+	}
 	profile, err := FindGameProfile(value)
 	if err != nil {
 		logging.L().Warn("dal failed", logging.Error(err))
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.BindingNickNotExist
+		retMsgForm.Message = resp.BindingNickNotExist
 		return
 	}
 
 	config, err := FindUserConfig(retMsgForm.UserId)
 	if err != nil {
 		logging.L().Warn("dal failed", logging.Error(err))
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.BindingError
+		retMsgForm.Message = resp.BindingError
 		return
 	}
-	if config.BindingGameNick != nil && *config.BindingGameNick != "" {
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.BindingExist
+	// 早期未经验证的绑定可以直接通过验证换绑
+	if config.BindingGameNick != nil && *config.BindingGameNick != "" &&
+		config.BindingVerified != nil && *config.BindingVerified {
+		retMsgForm.Message = resp.BindingExist
 		return
 	}
-	config.BindingGameNick = &profile.Nick
-	if err := SaveUserConfig(*config); err != nil {
-		logging.L().Warn("dal failed", logging.Error(err))
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.BindingError
+	if reachQueryLimit(retMsgForm) {
+		return
+	}
+	// 库内的称号可能已经过时，需要以最新的资料作为基准，否则称号早已变化的昵称可以被任何人绑定
+	missionId, err := RefreshWTUserInfo(profile.Nick, *retMsgForm)
+	if err != nil {
+		logging.L().Warn("refresh WT gamer profile error", logging.Error(err))
+		retMsgForm.Message = resp.CanNotRefresh
 		return
 	}
-	retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.BindingSuccess
+	retMsgForm.Message = resp.QueryIsRunning
+	sendForm := *retMsgForm
+	if err := ants.Submit(func() {
+		WaitForBindingStarted(*missionId, profile.Nick, sendForm)
+	}); err != nil {
+		logging.L().Error("submit ant job failed", logging.Error(err))
+	}
+	mustAddQueryCount(retMsgForm)
 }
 
-func DoActionUnbinding(retMsgForm *cqhttp.SendGroupMsgForm) {
+// DoActionBindingVerify 刷新待绑定玩家的游戏资料并核对称号是否已经换成其他称号
+func DoActionBindingVerify(retMsgForm *bot.Reply) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	if IsStopGlobalQuery() {
+		retMsgForm.Message = resp.StopGlobalQuery
+		return
+	}
+	challenge, err := FindBindingChallenge(retMsgForm.UserId)
+	if err != nil {
+		if errors.Is(err, ErrNoBindingChallenge) {
+			retMsgForm.Message = resp.BindingVerifyNotStarted
+			return
+		}
+		logging.L().Warn("find binding challenge failed", logging.Error(err))
+		retMsgForm.Message = resp.BindingError
+		return
+	}
+	if reachQueryLimit(retMsgForm) {
+		return
+	}
+	// 验证需要最新的游戏资料，不受刷新间隔的限制
+	missionId, err := RefreshWTUserInfo(challenge.Nick, *retMsgForm)
+	if err != nil {
+		logging.L().Warn("refresh WT gamer profile error", logging.Error(err))
+		retMsgForm.Message = resp.CanNotRefresh
+		return
+	}
+	retMsgForm.Message = resp.QueryIsRunning
+	sendForm := *retMsgForm
+	if err := ants.Submit(func() {
+		WaitForBindingVerified(*missionId, *challenge, sendForm)
+	}); err != nil {
+		logging.L().Error("submit ant job failed", logging.Error(err))
+	}
+	mustAddQueryCount(retMsgForm)
+}
+
+func DoActionUnbinding(retMsgForm *bot.Reply) {
 	if err := UpdateUserConfigBindingGameNick(retMsgForm.UserId, nil); err != nil {
 		logging.L().Warn("dal failed", logging.Error(err))
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.UnbindingError
@@ -300,7 +923,7 @@ func DoActionUnbinding(retMsgForm *cqhttp.SendGroupMsgForm) {
 	retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.UnbindingSuccess
 }
 
-func DoActionManager(retMsgForm *cqhttp.SendGroupMsgForm, uc *table.QQUserConfig, value string) {
+func DoActionManager(retMsgForm *bot.Reply, uc *table.QQUserConfig, value string) {
 	// 只有超级管理员可以进行全局设置
 	if uc.SuperAdmin == nil || !*uc.SuperAdmin {
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfNotPermit
@@ -313,35 +936,331 @@ func DoActionManager(retMsgForm *cqhttp.SendGroupMsgForm, uc *table.QQUserConfig
 	keyCloseQuery := "关闭查询"
 	keySetAdmin := "添加管理员"
 	keyUnsetAdmin := "解除管理员"
-	switch value {
+	keyBanUser := "封禁用户"
+	keyUnbanUser := "解封用户"
+	keyBanGroup := "封禁群"
+	keyUnbanGroup := "解封群"
+	keyUserQueryLimit := "用户查询上限"
+	keyUserUsageLimit := "用户使用上限"
+	keyGroupQueryLimit := "群查询上限"
+	keyGroupUsageLimit := "群使用上限"
+
+	split := strings.Fields(value)
+	var subCmd string
+	var args []string
+	if len(split) > 0 {
+		subCmd = split[0]
+		args = split[1:]
+	}
+	switch subCmd {
 	case keyOpenResponse:
-		MustUpsertGlobalConfig(table.ConfigStopAllResponse, "false")
+		updateGlobalConfigWithAudit(retMsgForm, table.ConfigStopAllResponse, "false")
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfStartGlobalResponse
 	case keyCloseResponse:
-		MustUpsertGlobalConfig(table.ConfigStopAllResponse, "true")
+		updateGlobalConfigWithAudit(retMsgForm, table.ConfigStopAllResponse, "true")
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfStopGlobalResponse
 	case keyOpenQuery:
-		MustUpsertGlobalConfig(table.ConfigStopQuery, "false")
+		updateGlobalConfigWithAudit(retMsgForm, table.ConfigStopQuery, "false")
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfStartGlobalQuery
 	case keyCloseQuery:
-		MustUpsertGlobalConfig(table.ConfigStopQuery, "true")
+		updateGlobalConfigWithAudit(retMsgForm, table.ConfigStopQuery, "true")
 		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfStopGlobalQuery
-	case keySetAdmin:
-	// 	TODO
-	case keyUnsetAdmin:
-	// 	TODO
+	case keySetAdmin, keyUnsetAdmin:
+		enable := subCmd == keySetAdmin
+		updateUserConfigWithAudit(retMsgForm, args, subCmd, botQueryPrefix+subCmd+" @用户或qq号",
+			func(target *table.QQUserConfig) (string, string) {
+				old := boolToFriendly(target.Admin != nil && *target.Admin)
+				target.Admin = &enable
+				return old, boolToFriendly(enable)
+			})
+	case keyBanUser, keyUnbanUser:
+		enable := subCmd == keyBanUser
+		updateUserConfigWithAudit(retMsgForm, args, subCmd, botQueryPrefix+subCmd+" @用户或qq号",
+			func(target *table.QQUserConfig) (string, string) {
+				old := boolToFriendly(target.Banned != nil && *target.Banned)
+				target.Banned = &enable
+				return old, boolToFriendly(enable)
+			})
+	case keyBanGroup, keyUnbanGroup:
+		enable := subCmd == keyBanGroup
+		updateGroupConfigWithAudit(retMsgForm, args, subCmd, botQueryPrefix+subCmd+" 群号",
+			func(target *table.QQGroupConfig) (string, string) {
+				old := boolToFriendly(target.Banned != nil && *target.Banned)
+				target.Banned = &enable
+				return old, boolToFriendly(enable)
+			})
+	case keyUserQueryLimit, keyUserUsageLimit:
+		limit, ok := parseLimitArg(args)
+		if !ok {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfTargetInvalid,
+				botQueryPrefix+subCmd+" @用户或qq号 次数")
+			return
+		}
+		updateUserConfigWithAudit(retMsgForm, args[:1], subCmd, botQueryPrefix+subCmd+" @用户或qq号 次数",
+			func(target *table.QQUserConfig) (string, string) {
+				field := &target.OneDayQueryLimit
+				if subCmd == keyUserUsageLimit {
+					field = &target.OneDayUsageLimit
+				}
+				old := strconv.Itoa(*field)
+				*field = limit
+				return old, strconv.Itoa(limit)
+			})
+	case keyGroupQueryLimit, keyGroupUsageLimit:
+		limit, ok := parseLimitArg(args)
+		if !ok {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfTargetInvalid,
+				botQueryPrefix+subCmd+" 群号 次数")
+			return
+		}
+		updateGroupConfigWithAudit(retMsgForm, args[:1], subCmd, botQueryPrefix+subCmd+" 群号 次数",
+			func(target *table.QQGroupConfig) (string, string) {
+				field := &target.OneDayQueryLimit
+				if subCmd == keyGroupUsageLimit {
+					field = &target.OneDayUsageLimit
+				}
+				old := strconv.Itoa(*field)
+				*field = limit
+				return old, strconv.Itoa(limit)
+			})
 	default:
 		var lst []string
 		lst = append(lst, botQueryPrefix+keyCloseResponse)
 		lst = append(lst, botQueryPrefix+keyOpenResponse)
 		lst = append(lst, botQueryPrefix+keyOpenQuery)
 		lst = append(lst, botQueryPrefix+keyCloseQuery)
-		lst = append(lst, botQueryPrefix+keySetAdmin)
-		lst = append(lst, botQueryPrefix+keyUnsetAdmin)
+		lst = append(lst, botQueryPrefix+keySetAdmin+" @用户或qq号")
+		lst = append(lst, botQueryPrefix+keyUnsetAdmin+" @用户或qq号")
+		lst = append(lst, botQueryPrefix+keyBanUser+" @用户或qq号")
+		lst = append(lst, botQueryPrefix+keyUnbanUser+" @用户或qq号")
+		lst = append(lst, botQueryPrefix+keyBanGroup+" 群号")
+		lst = append(lst, botQueryPrefix+keyUnbanGroup+" 群号")
+		lst = append(lst, botQueryPrefix+keyUserQueryLimit+" @用户或qq号 次数")
+		lst = append(lst, botQueryPrefix+keyUserUsageLimit+" @用户或qq号 次数")
+		lst = append(lst, botQueryPrefix+keyGroupQueryLimit+" 群号 次数")
+		lst = append(lst, botQueryPrefix+keyGroupUsageLimit+" 群号 次数")
 		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfOptions, strings.Join(lst, "\n"))
 	}
 }
 
-func DoActionGroupManager(retMsgForm *cqhttp.SendGroupMsgForm, uc *table.QQUserConfig, value string) {
-	// TODO 群管理
+// parseManageTarget 解析管理命令的目标，支持@用户或者直接输入的qq号、群号。
+// kook的用户和频道在平台无关的层中使用负数id，需要按kook的规则转换
+func parseManageTarget(platform string, arg string) (int64, bool) {
+	if platform == bot.PlatformKook {
+		return kook.ParseTarget(arg)
+	}
+	if cqhttp.MustContainsCqCode(arg) {
+		id := cqhttp.MustGetCqCodeAtQQ(arg)
+		return id, id > 0
+	}
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func parseLimitArg(args []string) (int, bool) {
+	if len(args) != 2 {
+		return 0, false
+	}
+	limit, err := strconv.Atoi(args[1])
+	if err != nil || limit < 0 {
+		return 0, false
+	}
+	return limit, true
+}
+
+func newAuditLog(retMsgForm *bot.Reply, action string, targetType string, targetId int64, oldValue string, newValue string) table.AuditLog {
+	return table.AuditLog{
+		OperatorId: retMsgForm.UserId,
+		Platform:   retMsgForm.Platform,
+		GroupId:    retMsgForm.GroupId,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+	}
+}
+
+func updateGlobalConfigWithAudit(retMsgForm *bot.Reply, key string, value string) {
+	var old string
+	if config := MustFindGlobalConfig(key); config != nil {
+		old = config.Value
+	}
+	MustUpsertGlobalConfig(key, value)
+	MustSaveAuditLog(newAuditLog(retMsgForm, key, table.AuditTargetGlobal, 0, old, value))
+}
+
+// updateUserConfigWithAudit 修改目标用户的配置并记录审计日志，apply返回修改前后的值用于回复和记录
+func updateUserConfigWithAudit(retMsgForm *bot.Reply, args []string, action string, usage string,
+	apply func(target *table.QQUserConfig) (string, string)) {
+	if len(args) != 1 {
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfTargetInvalid, usage)
+		return
+	}
+	targetId, ok := parseManageTarget(retMsgForm.Platform, args[0])
+	if !ok {
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfTargetInvalid, usage)
+		return
+	}
+	target, err := findOrCreateUserConfig(targetId)
+	if err != nil {
+		logging.L().Warn("dal failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfFailed
+		return
+	}
+	oldValue, newValue := apply(target)
+	if err := SaveUserConfig(*target); err != nil {
+		logging.L().Warn("dal failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfFailed
+		return
+	}
+	MustSaveAuditLog(newAuditLog(retMsgForm, action, table.AuditTargetUser, targetId, oldValue, newValue))
+	retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfSuccess,
+		fmt.Sprintf("%s %d", action, targetId), oldValue, newValue)
+}
+
+// updateGroupConfigWithAudit 修改目标群的配置并记录审计日志，apply返回修改前后的值用于回复和记录
+func updateGroupConfigWithAudit(retMsgForm *bot.Reply, args []string, action string, usage string,
+	apply func(target *table.QQGroupConfig) (string, string)) {
+	if len(args) != 1 {
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfTargetInvalid, usage)
+		return
+	}
+	targetId, ok := parseManageTarget(retMsgForm.Platform, args[0])
+	if !ok {
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfTargetInvalid, usage)
+		return
+	}
+	target, err := findOrCreateGroupConfig(targetId)
+	if err != nil {
+		logging.L().Warn("dal failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfFailed
+		return
+	}
+	oldValue, newValue := apply(target)
+	if err := SaveGroupConfig(*target); err != nil {
+		logging.L().Warn("dal failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfFailed
+		return
+	}
+	MustSaveAuditLog(newAuditLog(retMsgForm, action, table.AuditTargetGroup, targetId, oldValue, newValue))
+	retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.ConfSuccess,
+		fmt.Sprintf("%s %d", action, targetId), oldValue, newValue)
+}
+
+// groupConfigSwitch 群配置中可以通过群管理命令开关的选项
+type groupConfigSwitch struct {
+	keyOn  string
+	keyOff string
+	name   string
+	field  **bool
+	// 只有群主可以修改
+	ownerOnly bool
+	// 开启前需要先绑定直播间
+	requireBiliRoom bool
+}
+
+// DoActionGroupManager 群管理，群主和超级管理员总是可以修改本群配置，
+// 群管理员需要本群开启配置设置功能（由功能开关检查）并且允许管理员配置
+func DoActionGroupManager(retMsgForm *bot.Reply, uc *table.QQUserConfig, role string, value string) {
+	gc, err := FindGroupConfig(retMsgForm.GroupId)
+	if err != nil {
+		logging.L().Warn("dal failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfFailed
+		return
+	}
+	isSuperAdmin := uc.SuperAdmin != nil && *uc.SuperAdmin
+	isOwner := role == bot.RoleOwner || isSuperAdmin
+	isAdmin := role == bot.RoleAdmin && gc.AllowAdminConfig != nil && *gc.AllowAdminConfig
+	if !isOwner && !isAdmin {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfNotPermit
+		return
+	}
+
+	botQueryPrefix := ".cqbot 群管理 "
+	keyBindBiliRoom := "绑定直播间"
+	keySetTemplate := "切换语气"
+	switches := []groupConfigSwitch{
+		{keyOn: "开启查询", keyOff: "关闭查询", name: "启用战绩查询功能", field: &gc.EnableActionQuery},
+		{keyOn: "开启气运", keyOff: "关闭气运", name: "启用气运功能", field: &gc.EnableActionLuck},
+		{keyOn: "开启直播检查", keyOff: "关闭直播检查", name: "启用直播间检查功能", field: &gc.EnableCheckBiliRoom, requireBiliRoom: true},
+		{keyOn: "开启新闻推送", keyOff: "关闭新闻推送", name: "启用官网新闻推送功能", field: &gc.EnableCheckWTNew},
+		{keyOn: "停用全部功能", keyOff: "恢复全部功能", name: "停用全部功能", field: &gc.Shutdown},
+		{keyOn: "允许管理员配置", keyOff: "禁止管理员配置", name: "允许管理员配置", field: &gc.AllowAdminConfig, ownerOnly: true},
+		{keyOn: "开启配置设置", keyOff: "关闭配置设置", name: "启用配置设置功能", field: &gc.EnableActionSetting, ownerOnly: true},
+	}
+
+	var subCmd, arg string
+	if split := strings.Fields(value); len(split) > 0 {
+		subCmd = split[0]
+		arg = strings.Join(split[1:], " ")
+	}
+	for _, sw := range switches {
+		if subCmd != sw.keyOn && subCmd != sw.keyOff {
+			continue
+		}
+		if sw.ownerOnly && !isOwner {
+			retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfOwnerOnly
+			return
+		}
+		enable := subCmd == sw.keyOn
+		if enable && sw.requireBiliRoom && gc.BindBiliRoomId == 0 {
+			retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfBindBiliFirst
+			return
+		}
+		*sw.field = &enable
+		saveGroupConfigAndReply(retMsgForm, gc, sw.name, boolToFriendly(enable))
+		return
+	}
+
+	switch subCmd {
+	case keyBindBiliRoom:
+		roomId, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || roomId <= 0 {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfInvalidValue,
+				botQueryPrefix+keyBindBiliRoom+" 直播间号")
+			return
+		}
+		gc.BindBiliRoomId = roomId
+		saveGroupConfigAndReply(retMsgForm, gc, "绑定直播间号", arg)
+	case keySetTemplate:
+		templateId, err := strconv.Atoi(arg)
+		if err != nil || !slices.Contains(bot.MessageTemplates, templateId) {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfInvalidValue,
+				botQueryPrefix+keySetTemplate+" 0（默认）或 1（二次元）")
+			return
+		}
+		gc.MessageTemplate = templateId
+		// 切换后使用新的语气回复
+		retMsgForm.MessageTemplate = templateId
+		saveGroupConfigAndReply(retMsgForm, gc, "语气类型", arg)
+	default:
+		var lst []string
+		for _, sw := range switches {
+			lst = append(lst, botQueryPrefix+sw.keyOn)
+			lst = append(lst, botQueryPrefix+sw.keyOff)
+		}
+		lst = append(lst, botQueryPrefix+keyBindBiliRoom+" 直播间号")
+		lst = append(lst, botQueryPrefix+keySetTemplate+" 语气类型")
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfOptions, strings.Join(lst, "\n"))
+	}
+}
+
+func saveGroupConfigAndReply(retMsgForm *bot.Reply, gc *table.QQGroupConfig, name string, value string) {
+	if err := SaveGroupConfig(*gc); err != nil {
+		logging.L().Warn("dal failed", logging.Error(err))
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfFailed
+		return
+	}
+	retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupConfSuccess, name, value)
+}
+
+func boolToFriendly(b bool) string {
+	if b {
+		return "是"
+	}
+	return "否"
 }
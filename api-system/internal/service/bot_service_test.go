@@ -1,7 +1,11 @@
 package service
 
 import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 	"strconv"
 	"testing"
 	"time"
@@ -37,3 +41,61 @@ func TestDrawNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestParseManageTarget(t *testing.T) {
+	tests := []struct {
+		platform string
+		arg      string
+		id       int64
+		ok       bool
+	}{
+		{platform: bot.PlatformCqHttp, arg: "[CQ:at,qq=2362794289]", id: 2362794289, ok: true},
+		{platform: bot.PlatformCqHttp, arg: "2362794289", id: 2362794289, ok: true},
+		{platform: bot.PlatformCqHttp, arg: "[CQ:face,id=1]", id: 0, ok: false},
+		{platform: bot.PlatformCqHttp, arg: "abc", id: 0, ok: false},
+		{platform: bot.PlatformCqHttp, arg: "-1", id: 0, ok: false},
+		{platform: bot.PlatformCqHttp, arg: "(met)2418200000(met)", id: 0, ok: false},
+		// kook的用户在平台无关的层中为负数，不能与相同数值的qq号混用
+		{platform: bot.PlatformKook, arg: "(met)2418200000(met)", id: -2418200000, ok: true},
+		{platform: bot.PlatformKook, arg: "2418200000", id: -2418200000, ok: true},
+		{platform: bot.PlatformKook, arg: "-2418200000", id: -2418200000, ok: true},
+		{platform: bot.PlatformKook, arg: "(met)-2418200000(met)", id: 0, ok: false},
+		{platform: bot.PlatformKook, arg: "[CQ:at,qq=2362794289]", id: 0, ok: false},
+		{platform: bot.PlatformKook, arg: "0", id: 0, ok: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			id, ok := parseManageTarget(tt.platform, tt.arg)
+			assert.Equal(t, tt.id, id)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+// TestDoActionManagerKookTarget kook上通过@封禁用户和添加管理员，修改的是kook用户对应的配置而不是相同数值的qq号
+func TestDoActionManagerKookTarget(t *testing.T) {
+	setupTestData(t)
+	const kookUserId = 2418200000
+	uc := dal.QQUserConfig
+	t.Cleanup(func() {
+		_, _ = uc.Unscoped().Where(uc.UserId.In(kookUserId, -kookUserId)).Delete()
+		_, _ = dal.AuditLog.Unscoped().Where(dal.AuditLog.TargetId.Eq(-kookUserId)).Delete()
+	})
+	superAdmin := table.DefaultUserConfig(-1)
+	trueVal := true
+	superAdmin.SuperAdmin = &trueVal
+
+	for _, value := range []string{"封禁用户 (met)2418200000(met)", "添加管理员 (met)2418200000(met)"} {
+		reply := bot.Reply{Platform: bot.PlatformKook, MessageType: bot.MessageTypeGroup, GroupId: -6480729836729623, UserId: -1}
+		DoActionManager(&reply, &superAdmin, value)
+		assert.NotEmpty(t, reply.Message)
+	}
+
+	target, err := FindUserConfig(-kookUserId)
+	if assert.NoError(t, err) {
+		assert.True(t, *target.Banned)
+		assert.True(t, *target.Admin)
+	}
+	_, err = FindUserConfig(kookUserId)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
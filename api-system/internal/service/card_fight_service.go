@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/cache"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"math/big"
+	"time"
+)
+
+const (
+	// cardDrawDailyLimit 每人每天可以抽卡的次数
+	cardDrawDailyLimit = 3
+	// cardFightRankSize 对战排行展示的人数
+	cardFightRankSize = 10
+	// cardTeamSize 团战时每一方出战的卡牌数量
+	cardTeamSize = 3
+)
+
+var ErrCardDrawLimit = errors.New("reach card draw daily limit")
+
+// DrawUserCard 从卡池中为用户抽取一张卡牌并放入用户的卡牌库
+func DrawUserCard(userId int64) (*table.UserCard, error) {
+	client := cache.Client()
+	key := cache.GenerateCardDrawCacheKey(userId, time.Now().In(time.FixedZone("CST", 8*3600)).Format("2006-01-02"))
+	count, err := client.Incr(context.Background(), key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		if err := client.Expire(context.Background(), key, time.Hour*24).Err(); err != nil {
+			logging.L().Warn("set cache expire failed", logging.Error(err))
+		}
+	}
+	if count > cardDrawDailyLimit {
+		return nil, ErrCardDrawLimit
+	}
+	card := table.NewUserCard(userId, cardfight.DrawCard())
+	if err := dal.UserCard.Save(&card); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func FindUserCards(userId int64) ([]*table.UserCard, error) {
+	return dal.UserCard.Where(dal.UserCard.UserId.Eq(userId)).Order(dal.UserCard.ID).Find()
+}
+
+// PickUserCard 按名称选择用户的一张卡牌，名称为空时随机选择。没有卡牌时返回gorm.ErrRecordNotFound
+func PickUserCard(userId int64, name string) (*table.UserCard, error) {
+	uc := dal.UserCard
+	if name != "" {
+		return uc.Where(uc.UserId.Eq(userId), uc.Name.Eq(name)).First()
+	}
+	cards, err := FindUserCards(userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(cards))))
+	if err != nil {
+		return nil, err
+	}
+	return cards[n.Int64()], nil
+}
+
+// PickUserCards 随机选择用户的至多n张卡牌。没有卡牌时返回gorm.ErrRecordNotFound
+func PickUserCards(userId int64, n int) ([]*table.UserCard, error) {
+	cards, err := FindUserCards(userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	for i := len(cards) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		cards[i], cards[j.Int64()] = cards[j.Int64()], cards[i]
+	}
+	if len(cards) > n {
+		cards = cards[:n]
+	}
+	return cards, nil
+}
+
+// CardTeamFight 双方使用多张卡牌进行团战，并更新双方的战绩。团战不保存对战记录
+func CardTeamFight(groupId int64, teamA []*table.UserCard, teamB []*table.UserCard) cardfight.FightResult {
+	match := cardfight.TeamMatch{Seed: cardfight.NewSeed()}
+	for _, card := range teamA {
+		match.A = append(match.A, card.NewItem())
+	}
+	for _, card := range teamB {
+		match.B = append(match.B, card.NewItem())
+	}
+	result := match.FightWithResult()
+	MustAddCardFightResult(groupId, teamA[0].UserId, teamB[0].UserId, result.Outcome)
+	return result
+}
+
+// CardFight 使用双方的卡牌进行一场对战，保存对战记录并更新双方的战绩
+func CardFight(groupId int64, cardA *table.UserCard, cardB *table.UserCard) (*table.CardFightRecord, *cardfight.FightResult, error) {
+	record := newCardFightRecord(groupId, cardA, cardB)
+	result, err := saveCardFight(&record)
+	if err != nil {
+		return nil, nil, err
+	}
+	MustAddCardFightResult(groupId, record.UserIdA, record.UserIdB, result.Outcome)
+	return &record, result, nil
+}
+
+func newCardFightRecord(groupId int64, cardA *table.UserCard, cardB *table.UserCard) table.CardFightRecord {
+	return table.CardFightRecord{
+		GroupId: groupId,
+		UserIdA: cardA.UserId,
+		UserIdB: cardB.UserId,
+		CardA:   cardA.ToFightCard(),
+		CardB:   cardB.ToFightCard(),
+		Seed:    cardfight.NewSeed(),
+	}
+}
+
+// saveCardFight 进行对战并保存对战记录，之后可以通过记录回放对战
+func saveCardFight(record *table.CardFightRecord) (*cardfight.FightResult, error) {
+	result := record.Replay()
+	record.Outcome = result.Outcome
+	if err := dal.CardFightRecord.Save(record); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func FindCardFightRecord(id uint) (*table.CardFightRecord, error) {
+	return dal.CardFightRecord.Where(dal.CardFightRecord.ID.Eq(id)).Take()
+}
+
+// MustAddCardFightResult 记录一场对战的结果
+func MustAddCardFightResult(groupId int64, userIdA int64, userIdB int64, result int) {
+	switch result {
+	case cardfight.FightResultAWin:
+		mustAddCardFightStat(groupId, userIdA, dal.CardFightStat.Win)
+		mustAddCardFightStat(groupId, userIdB, dal.CardFightStat.Lose)
+	case cardfight.FightResultBWin:
+		mustAddCardFightStat(groupId, userIdA, dal.CardFightStat.Lose)
+		mustAddCardFightStat(groupId, userIdB, dal.CardFightStat.Win)
+	default:
+		mustAddCardFightStat(groupId, userIdA, dal.CardFightStat.Draw)
+		mustAddCardFightStat(groupId, userIdB, dal.CardFightStat.Draw)
+	}
+}
+
+func mustAddCardFightStat(groupId int64, userId int64, column field.Int) {
+	cfs := dal.CardFightStat
+	stat, err := cfs.Where(cfs.GroupId.Eq(groupId), cfs.UserId.Eq(userId)).
+		FirstOrCreate()
+	if err != nil {
+		logging.L().Error("dal error", logging.Error(err))
+		return
+	}
+	if _, err := cfs.Where(cfs.ID.Eq(stat.ID)).UpdateSimple(column.Add(1)); err != nil {
+		logging.L().Error("dal error", logging.Error(err))
+	}
+}
+
+// FindCardFightRank 群内的对战排行，按胜场数排序
+func FindCardFightRank(groupId int64) ([]*table.CardFightStat, error) {
+	cfs := dal.CardFightStat
+	return cfs.Where(cfs.GroupId.Eq(groupId)).
+		Order(cfs.Win.Desc(), cfs.Lose, cfs.UserId).
+		Limit(cardFightRankSize).
+		Find()
+}
+
+// FindCatalogueCards 分页查询卡牌图鉴，同时返回卡牌总数
+func FindCatalogueCards(offset int, limit int) ([]display.CatalogueCard, int) {
+	catalogue := cardfight.DefaultCatalogue()
+	total := len(catalogue.Cards)
+	ret := make([]display.CatalogueCard, 0)
+	for i := offset; i < total && i < offset+limit; i++ {
+		ret = append(ret, catalogueCardToDisplay(catalogue, catalogue.Cards[i]))
+	}
+	return ret, total
+}
+
+// FindCatalogueCard 按名称查询卡牌图鉴中的卡牌
+func FindCatalogueCard(name string) (*display.CatalogueCard, bool) {
+	catalogue := cardfight.DefaultCatalogue()
+	card, ok := catalogue.Find(name)
+	if !ok {
+		return nil, false
+	}
+	ret := catalogueCardToDisplay(catalogue, card)
+	return &ret, true
+}
+
+func catalogueCardToDisplay(catalogue *cardfight.Catalogue, card cardfight.Card) display.CatalogueCard {
+	return display.CatalogueCard{
+		Name:         card.Name,
+		Nation:       card.Nation,
+		BattleRating: card.BattleRating,
+		Rank:         card.Rank,
+		Class:        cardfight.ClassName(card.Class),
+		Velocity:     card.Velocity,
+		Firepower:    card.Firepower,
+		Protection:   card.Protection,
+		Rarity:       cardfight.RarityName(card.Rarity),
+		Probability:  catalogue.Probability(card),
+	}
+}
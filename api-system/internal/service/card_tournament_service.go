@@ -0,0 +1,250 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"gorm.io/gorm"
+)
+
+// cardTournamentMinEntries 锦标赛开始所需的最少报名人数
+const cardTournamentMinEntries = 2
+
+var (
+	ErrTournamentExist          = errors.New("card tournament already exist")
+	ErrTournamentNotRegistering = errors.New("card tournament is not registering")
+	ErrTournamentEntryExist     = errors.New("already joined card tournament")
+	ErrTournamentNotEnoughEntry = errors.New("not enough card tournament entries")
+)
+
+var tournamentStatusNames = map[string]string{
+	table.TournamentStatusRegistering: "报名中",
+	table.TournamentStatusRunning:     "比赛中",
+	table.TournamentStatusFinished:    "已结束",
+	table.TournamentStatusCanceled:    "已取消",
+}
+
+// FindActiveCardTournament 群内正在报名或者正在进行的锦标赛
+func FindActiveCardTournament(groupId int64) (*table.CardTournament, error) {
+	ct := dal.CardTournament
+	return ct.Where(ct.GroupId.Eq(groupId),
+		ct.Status.In(table.TournamentStatusRegistering, table.TournamentStatusRunning)).
+		Order(ct.ID.Desc()).First()
+}
+
+func CreateCardTournament(retMsgForm *bot.Reply) (*table.CardTournament, error) {
+	if _, err := FindActiveCardTournament(retMsgForm.GroupId); err == nil {
+		return nil, ErrTournamentExist
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	tournament := table.CardTournament{
+		GroupId:         retMsgForm.GroupId,
+		Platform:        retMsgForm.Platform,
+		SelfId:          retMsgForm.SelfId,
+		MessageTemplate: retMsgForm.MessageTemplate,
+		CreatorId:       retMsgForm.UserId,
+		Status:          table.TournamentStatusRegistering,
+	}
+	if err := dal.CardTournament.Save(&tournament); err != nil {
+		return nil, err
+	}
+	return &tournament, nil
+}
+
+func FindCardTournamentEntries(tournamentId uint) ([]*table.CardTournamentEntry, error) {
+	cte := dal.CardTournamentEntry
+	return cte.Where(cte.TournamentId.Eq(tournamentId)).Order(cte.ID).Find()
+}
+
+// JoinCardTournament 使用指定的卡牌报名锦标赛，每人只能报名一次
+func JoinCardTournament(tournament *table.CardTournament, card *table.UserCard) error {
+	if tournament.Status != table.TournamentStatusRegistering {
+		return ErrTournamentNotRegistering
+	}
+	cte := dal.CardTournamentEntry
+	count, err := cte.Where(cte.TournamentId.Eq(tournament.ID), cte.UserId.Eq(card.UserId)).Count()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTournamentEntryExist
+	}
+	return cte.Save(&table.CardTournamentEntry{
+		TournamentId: tournament.ID,
+		UserId:       card.UserId,
+		Card:         card.ToFightCard(),
+	})
+}
+
+// StartCardTournament 结束报名，之后由定时任务逐轮进行比赛
+func StartCardTournament(tournament *table.CardTournament) error {
+	if tournament.Status != table.TournamentStatusRegistering {
+		return ErrTournamentNotRegistering
+	}
+	cte := dal.CardTournamentEntry
+	count, err := cte.Where(cte.TournamentId.Eq(tournament.ID)).Count()
+	if err != nil {
+		return err
+	}
+	if count < cardTournamentMinEntries {
+		return ErrTournamentNotEnoughEntry
+	}
+	tournament.Status = table.TournamentStatusRunning
+	return dal.CardTournament.Save(tournament)
+}
+
+func CancelCardTournament(tournament *table.CardTournament) error {
+	tournament.Status = table.TournamentStatusCanceled
+	return dal.CardTournament.Save(tournament)
+}
+
+// CardTournamentToDisplay 锦标赛的状态以及报名的玩家
+func CardTournamentToDisplay(tournament *table.CardTournament, platform string) (*display.CardTournament, error) {
+	entries, err := FindCardTournamentEntries(tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	ret := display.CardTournament{
+		Id:     tournament.ID,
+		Status: tournamentStatusNames[tournament.Status],
+		Round:  tournament.Round,
+	}
+	for _, entry := range entries {
+		text := fmt.Sprintf("%s（%s）", bot.Mention(platform, entry.UserId), entry.Card.Name)
+		if entry.EliminatedRound > 0 {
+			text += fmt.Sprintf("，第%d轮淘汰", entry.EliminatedRound)
+		}
+		ret.Entries = append(ret.Entries, text)
+	}
+	return &ret, nil
+}
+
+// RunCardTournamentRound 进行锦标赛的下一轮比赛，按报名顺序两两对战，人数为奇数时最后一人轮空。
+// 平局时使用对战的随机数种子抽签决定晋级的一方，只剩一人时锦标赛结束
+func RunCardTournamentRound(tournament *table.CardTournament, platform string) (*display.CardTournamentRound, error) {
+	entries, err := FindCardTournamentEntries(tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	var alive []*table.CardTournamentEntry
+	for _, entry := range entries {
+		if entry.EliminatedRound == 0 {
+			alive = append(alive, entry)
+		}
+	}
+	round := tournament.Round + 1
+	ret := display.CardTournamentRound{Round: round}
+	var eliminated []*table.CardTournamentEntry
+	for i := 0; i+1 < len(alive); i += 2 {
+		a, b := alive[i], alive[i+1]
+		cardA, cardB := a.ToUserCard(), b.ToUserCard()
+		record := newCardFightRecord(tournament.GroupId, &cardA, &cardB)
+		record.TournamentId = tournament.ID
+		record.Round = round
+		result, err := saveCardFight(&record)
+		if err != nil {
+			return nil, err
+		}
+		MustAddCardFightResult(tournament.GroupId, a.UserId, b.UserId, result.Outcome)
+		winner, loser := a, b
+		switch result.Outcome {
+		case cardfight.FightResultBWin:
+			winner, loser = b, a
+		case cardfight.FightResultDraw:
+			if cardfight.NewDice(record.Seed).Intn(2) == 1 {
+				winner, loser = b, a
+			}
+		}
+		eliminated = append(eliminated, loser)
+		ret.Matches = append(ret.Matches, display.CardTournamentMatch{
+			RecordId: record.ID,
+			UserA:    bot.Mention(platform, a.UserId),
+			CardA:    a.Card.Name,
+			UserB:    bot.Mention(platform, b.UserId),
+			CardB:    b.Card.Name,
+			Winner:   bot.Mention(platform, winner.UserId),
+			Draw:     result.Outcome == cardfight.FightResultDraw,
+		})
+	}
+	if len(alive)%2 == 1 {
+		ret.Bye = bot.Mention(platform, alive[len(alive)-1].UserId)
+	}
+	for _, entry := range eliminated {
+		entry.EliminatedRound = round
+		if err := dal.CardTournamentEntry.Save(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	tournament.Round = round
+	if remain := len(alive) - len(eliminated); remain <= 1 {
+		tournament.Status = table.TournamentStatusFinished
+		for _, entry := range alive {
+			if entry.EliminatedRound == 0 {
+				tournament.ChampionId = entry.UserId
+				ret.Champion = bot.Mention(platform, entry.UserId)
+			}
+		}
+	}
+	if err := dal.CardTournament.Save(tournament); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func FindRunningCardTournaments() ([]*table.CardTournament, error) {
+	ct := dal.CardTournament
+	return ct.Where(ct.Status.Eq(table.TournamentStatusRunning)).Find()
+}
+
+// cardTournamentPlatform 锦标赛创建时使用的平台，之前没有记录平台的锦标赛都来自cqhttp
+func cardTournamentPlatform(tournament *table.CardTournament) string {
+	if tournament.Platform == "" {
+		return bot.PlatformCqHttp
+	}
+	return tournament.Platform
+}
+
+// RunCardTournaments 为进行中的锦标赛进行下一轮比赛，并将本轮结果发送到群内。
+// 群被封禁、停用或关闭了气运功能时暂停比赛，恢复后继续
+func RunCardTournaments() error {
+	tournaments, err := FindRunningCardTournaments()
+	if err != nil {
+		return err
+	}
+	for _, tournament := range tournaments {
+		gc, err := FindGroupConfig(tournament.GroupId)
+		if err != nil {
+			logging.L().Warn("find group config failed",
+				logging.Error(err),
+				logging.Any("group", tournament.GroupId))
+			continue
+		}
+		if !isGroupFeatureEnabled(gc, bot.ActionTournament) {
+			continue
+		}
+		platform := cardTournamentPlatform(tournament)
+		round, err := RunCardTournamentRound(tournament, platform)
+		if err != nil {
+			logging.L().Error("run card tournament round failed",
+				logging.Error(err),
+				logging.Any("tournament", tournament.ID))
+			continue
+		}
+		bot.MustSend(bot.Reply{
+			Platform:        platform,
+			SelfId:          tournament.SelfId,
+			MessageType:     bot.MessageTypeGroup,
+			GroupId:         tournament.GroupId,
+			Message:         round.ToFriendlyString(),
+			MessageTemplate: tournament.MessageTemplate,
+		})
+	}
+	return nil
+}
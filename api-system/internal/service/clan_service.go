@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/cache"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"net/url"
+	"path"
+	"time"
+)
+
+const clanRankSize = 10
+
+// ErrClanUnknown 库内既没有联队资料，也没有该联队成员的数据，无法得知联队页面的地址
+var ErrClanUnknown = errors.New("clan unknown")
+
+type ClanScheduleForm struct {
+	Tag string `json:"tag"`
+	// 联队页面地址中的联队全称
+	Name     string    `json:"name"`
+	SendForm bot.Reply `json:"send_form"`
+}
+
+func FindGameClan(tag string) (*table.GameClan, error) {
+	gc := dal.GameClan
+	return gc.Where(gc.Tag.Eq(tag)).Take()
+}
+
+func CanClanBeRefresh(tag string) bool {
+	key := cache.GenerateGameClanCacheKey(tag)
+	if _, err := cache.Client().Get(context.Background(), key).Result(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return true
+		}
+		logging.L().Error("get cache error", logging.Error(err))
+		return false
+	}
+	return false
+}
+
+func MustPutClanRefreshFlag(tag string) {
+	key := cache.GenerateGameClanCacheKey(tag)
+	if err := cache.Client().Set(context.Background(), key, "", time.Hour*24).Err(); err != nil {
+		logging.L().Error("set cache error", logging.Error(err))
+	}
+}
+
+// findClanName 查找联队页面地址中的联队全称，优先使用已保存的联队资料，其次使用成员资料中的联队地址
+func findClanName(tag string) (string, error) {
+	var clanUrl string
+	if clan, err := FindGameClan(tag); err == nil {
+		clanUrl = clan.Url
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	if clanUrl == "" {
+		gu := dal.GameUser
+		user, err := gu.Where(gu.Clan.Eq(tag), gu.ClanUrl.Neq("")).Order(gu.UpdatedAt.Desc()).First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", ErrClanUnknown
+			}
+			return "", err
+		}
+		clanUrl = user.ClanUrl
+	}
+	u, err := url.Parse(clanUrl)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		return "", ErrClanUnknown
+	}
+	return name, nil
+}
+
+// RefreshGameClan 提交刷新联队资料的任务，同一个联队正在爬取时直接返回正在执行的任务id
+func RefreshGameClan(tag string, sendForm bot.Reply) (*string, error) {
+	name, err := findClanName(tag)
+	if err != nil {
+		return nil, err
+	}
+	form := ClanScheduleForm{
+		Tag:      tag,
+		Name:     name,
+		SendForm: sendForm,
+	}
+	missionId, submitted, err := SubmitDedupMission(table.MissionTypeClan, tag, form)
+	if err != nil {
+		return nil, err
+	}
+	if !submitted {
+		logging.L().Info("attach to running mission",
+			logging.Any("clan", tag),
+			logging.Any("missionId", missionId))
+	}
+	return &missionId, nil
+}
+
+// handleClanMission 爬取联队页面并保存联队资料和成员列表，查询失败时返回错误以便重试
+func handleClanMission(mission *table.Mission) (any, error) {
+	var form ClanScheduleForm
+	if err := json.Unmarshal([]byte(mission.Detail), &form); err != nil {
+		return nil, err
+	}
+	var status int
+	var clan *table.GameClan
+	var members []table.GameClanMember
+	if err := crawler.GetClanFromWTOfficial(form.Name, func(s int, c *table.GameClan, m []table.GameClanMember) {
+		status, clan, members = s, c, m
+	}); err != nil {
+		return CrawlerResult{Found: false, Nick: form.Tag}, errors.Join(ErrCrawlerQueryFailed, err)
+	}
+	MustPutClanRefreshFlag(form.Tag)
+	if status != crawler.StatusFound {
+		return CrawlerResult{Found: false, Nick: form.Tag}, nil
+	}
+	if clan.Tag != form.Tag {
+		logging.L().Warn("clan tag changed",
+			logging.Any("tag", form.Tag),
+			logging.Any("crawled", clan.Tag))
+	}
+	// 以玩家资料中的联队标签保存，便于和玩家数据关联
+	clan.Tag = form.Tag
+	for i := range members {
+		members[i].Tag = form.Tag
+	}
+	if err := saveGameClan(clan, members); err != nil {
+		return nil, err
+	}
+	return CrawlerResult{Found: true, Nick: form.Tag, Data: clan}, nil
+}
+
+// saveGameClan 保存联队资料，并用本次爬取的成员列表替换原有的成员列表
+func saveGameClan(clan *table.GameClan, members []table.GameClanMember) error {
+	return dal.Q.Transaction(func(tx *dal.Query) error {
+		gc := tx.GameClan
+		saved, err := gc.Where(gc.Tag.Eq(clan.Tag)).FirstOrCreate()
+		if err != nil {
+			return err
+		}
+		saved.Name = clan.Name
+		saved.Url = clan.Url
+		saved.Description = clan.Description
+		saved.RegisterDate = clan.RegisterDate
+		saved.MemberCount = clan.MemberCount
+		if err := gc.Save(saved); err != nil {
+			return err
+		}
+		gcm := tx.GameClanMember
+		if _, err := gcm.Unscoped().Where(gcm.Tag.Eq(clan.Tag)).Delete(); err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		items := make([]*table.GameClanMember, len(members))
+		for i := range members {
+			items[i] = &members[i]
+		}
+		return gcm.CreateInBatches(items, 100)
+	})
+}
+
+// findClanRoster 联队页面中的成员列表以及库内收录的成员的玩家数据
+func findClanRoster(tag string) ([]table.GameClanMember, []table.GameUser, error) {
+	gcm := dal.GameClanMember
+	members, err := gcm.Where(gcm.Tag.Eq(tag)).Find()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil, nil
+	}
+	nicks := make([]string, len(members))
+	retMembers := make([]table.GameClanMember, len(members))
+	for i, m := range members {
+		nicks[i] = m.Nick
+		retMembers[i] = *m
+	}
+	gu := dal.GameUser
+	users, err := gu.Where(gu.Nick.In(nicks...)).Find()
+	if err != nil {
+		return nil, nil, err
+	}
+	retUsers := make([]table.GameUser, len(users))
+	for i, u := range users {
+		retUsers[i] = *u
+	}
+	return retMembers, retUsers, nil
+}
+
+// FindGameClanDisplay 联队资料、成员列表以及库内成员数据的统计
+func FindGameClanDisplay(tag string) (*display.GameClan, error) {
+	clan, err := FindGameClan(tag)
+	if err != nil {
+		return nil, err
+	}
+	members, users, err := findClanRoster(tag)
+	if err != nil {
+		return nil, err
+	}
+	stat := table.ClanStat{Tag: tag}
+	if s, ok := table.NewClanStats(members, users)[tag]; ok {
+		stat = *s
+	}
+	ret := clan.ToDisplay(stat, table.ClanRoster(members, users))
+	return &ret, nil
+}
+
+// FindClanRank 按成员的历史平均胜率对库内的联队排行，成员以联队页面中的成员列表为准
+func FindClanRank() (*display.ClanRank, error) {
+	gcm := dal.GameClanMember
+	members, err := gcm.Select(gcm.Tag, gcm.Nick).Order(gcm.ID).Find()
+	if err != nil {
+		return nil, err
+	}
+	// 成员列表更新不及时时同一个玩家可能出现在多个联队中，只计入最后一次爬取到的联队。
+	// 每次爬取都会重新写入成员列表，id越大越新
+	tags := make(map[string]string, len(members))
+	for _, m := range members {
+		tags[m.Nick] = m.Tag
+	}
+	stats := make(table.ClanStats)
+	var batch []*table.GameUser
+	gu := dal.GameUser
+	if err := gu.Where(gu.Columns(gu.Nick).In(gcm.Select(gcm.Nick))).
+		FindInBatches(&batch, ratingBatchSize, func(tx gen.Dao, _ int) error {
+			for _, user := range batch {
+				stats.Add(tags[user.Nick], *user)
+			}
+			return nil
+		}); err != nil {
+		return nil, err
+	}
+	rank := display.ClanRank{MinMember: table.ClanRankMinMember}
+	for i, stat := range stats.Rank(clanRankSize) {
+		item := stat.ToDisplayRankItem()
+		item.Rank = i + 1
+		rank.Items = append(rank.Items, item)
+	}
+	return &rank, nil
+}
+
+// WaitForClanFinished 等待联队爬取任务结束后将联队资料发送给用户
+func WaitForClanFinished(missionId string, tag string, sendForm bot.Reply) {
+	if !WaitForMissionsFinished([]string{missionId}) {
+		sendForm.Message = "对不起，查询超时，请稍后重试"
+	} else if mission, err := FindMission(missionId); err != nil || mission.Status == table.MissionStatusFailed {
+		sendForm.Message = bot.SelectStaticMessage(sendForm.MessageTemplate).CommonResp.ClanFailed
+	} else {
+		fillClanMessage(&sendForm, tag)
+	}
+	bot.MustSend(sendForm)
+}
+
+// fillClanMessage 将库内的联队资料写入回复，没有联队资料时写入未找到的提示
+func fillClanMessage(retMsgForm *bot.Reply, tag string) {
+	resp := bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp
+	clan, err := FindGameClanDisplay(tag)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			retMsgForm.Message = fmt.Sprintf(resp.ClanNotFound, tag)
+			return
+		}
+		logging.L().Warn("find game clan failed", logging.Error(err))
+		retMsgForm.Message = resp.ClanFailed
+		return
+	}
+	retMsgForm.Message = clan.ToFriendlyString()
+}
@@ -4,15 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/axiangcoding/antonstar-bot/internal/cache"
-	"github.com/axiangcoding/antonstar-bot/internal/data/table"
-	"github.com/axiangcoding/antonstar-bot/pkg/bot"
 	"github.com/axiangcoding/antonstar-bot/pkg/cqhttp"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
-	"github.com/axiangcoding/antonstar-bot/setting"
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 	"golang.org/x/exp/slices"
-	"gorm.io/gorm"
 	"time"
 )
 
@@ -42,8 +38,8 @@ func HandleCqHttpEvent(c *gin.Context, data map[string]any) error {
 				return err
 			}
 			switch data["message_type"] {
-			case cqhttp.MessageTypeGroup:
-				handleCqHttpMessageEventGroup(&event)
+			case cqhttp.MessageTypeGroup, cqhttp.MessageTypePrivate:
+				HandleBotMessage(event.ToBotMessage())
 				break
 			default:
 				logging.L().Warn("message_type not supported yet",
@@ -67,7 +63,15 @@ func HandleCqHttpEvent(c *gin.Context, data map[string]any) error {
 				break
 			}
 		case cqhttp.PostTypeNotice:
-			break
+			var event cqhttp.CommonEvent
+			if err := mapstructure.Decode(data, &event); err != nil {
+				return err
+			}
+			switch data["notice_type"] {
+			case cqhttp.NoticeTypeGroupDecrease:
+				handleGroupDecrease(&event)
+				break
+			}
 		}
 	} else {
 		return errors.New("no such event_type")
@@ -95,124 +99,6 @@ func handleCqHttpMetaEventHeartBeat(c *gin.Context, event *cqhttp.MetaTypeHeartB
 	}
 }
 
-func handleCqHttpMessageEventGroup(event *cqhttp.CommonEvent) {
-	messageType := event.MessageType
-	msg := event.Message
-	if messageType != "group" || !cqhttp.MustContainsTrigger(msg) {
-		return
-	}
-	action := bot.ParseMessageCommand(msg)
-	stopAllResponse := IsStopAllResponse()
-	if stopAllResponse && (action == nil || action.Key != bot.ActionManager) {
-		return
-	}
-	groupId := event.GroupId
-	userId := event.UserId
-	gc, err := FindGroupConfig(groupId)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			defaultGC := table.DefaultGroupConfig(groupId)
-			gc = &defaultGC
-			MustSaveGroupConfig(gc)
-		} else {
-			logging.L().Warn("find group config failed", logging.Error(err))
-		}
-	}
-	uc, err := FindUserConfig(userId)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			defaultUC := table.DefaultUserConfig(userId)
-			uc = &defaultUC
-			MustSaveUserConfig(uc)
-		} else {
-			logging.L().Warn("find user config failed", logging.Error(err))
-		}
-	}
-	var retMsgForm cqhttp.SendGroupMsgForm
-	retMsgForm.GroupId = groupId
-	retMsgForm.MessageTemplate = gc.MessageTemplate
-	retMsgForm.UserId = userId
-	// 检查qq群请求限制
-	if limit, usage, total := CheckGroupTodayUsageLimit(groupId); limit {
-		if !ExistGroupUsageLimitFlag(groupId) {
-			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayGroupUsageLimit, usage, total)
-			cqhttp.MustSendGroupMsg(retMsgForm)
-			MustPutGroupUsageLimitFlag(groupId)
-			return
-		} else {
-			return
-		}
-	}
-	// 检查qq请求限制
-	if limit, usage, total := CheckUserTodayUsageLimit(userId); limit {
-		if !ExistUserUsageLimitFlag(userId) {
-			retMsgForm.MessagePrefix = fmt.Sprintf("[CQ:at,qq=%d] ", event.Sender.UserId)
-			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayUserUsageLimit, usage, total)
-			cqhttp.MustSendGroupMsg(retMsgForm)
-			MustPutUserUsageLimitFlag(userId)
-			return
-		} else {
-			return
-		}
-	}
-	MustAddGroupConfigTodayUsageCount(groupId, 1)
-	MustAddGroupConfigTotalUsageCount(groupId, 1)
-	MustAddUserConfigTodayUsageCount(userId, 1)
-	MustAddUserConfigTotalUsageCount(userId, 1)
-
-	if *gc.Banned {
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupGetBanned
-		cqhttp.MustSendGroupMsg(retMsgForm)
-		return
-	}
-	if *uc.Banned {
-		retMsgForm.MessagePrefix = fmt.Sprintf("[CQ:at,qq=%d] ", event.Sender.UserId)
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.UserGetBanned
-		cqhttp.MustSendGroupMsg(retMsgForm)
-		return
-	}
-	retMsgForm.MessagePrefix = fmt.Sprintf("[CQ:at,qq=%d] ", event.Sender.UserId)
-	if action == nil {
-		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Common
-	} else {
-		value := action.Value
-		switch action.Key {
-		case bot.ActionQuery:
-			DoActionQuery(&retMsgForm, value, false)
-		case bot.ActionFullQuery:
-			DoActionQuery(&retMsgForm, value, true)
-		case bot.ActionRefresh:
-			DoActionRefresh(&retMsgForm, value)
-		case bot.ActionReport:
-			retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Report
-		case bot.ActionDrawCard:
-			DoActionDrawCard(&retMsgForm, value, event.Sender.UserId)
-		case bot.ActionLuck:
-			DoActionLuck(&retMsgForm, value, event.Sender.UserId)
-		case bot.ActionVersion:
-			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Version, setting.C().App.Version)
-		case bot.ActionGetHelp:
-			retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GetHelp
-		case bot.ActionGroupStatus:
-			DoActionGroupStatus(&retMsgForm)
-		case bot.ActionGroupManager:
-			DoActionGroupManager(&retMsgForm, uc, value)
-		case bot.ActionData:
-			DoActionData(&retMsgForm, value)
-		case bot.ActionBinding:
-			DoActionBinding(&retMsgForm, value)
-		case bot.ActionUnbinding:
-			DoActionUnbinding(&retMsgForm)
-		case bot.ActionManager:
-			DoActionManager(&retMsgForm, uc, value)
-		default:
-			retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GetHelp
-		}
-	}
-
-	cqhttp.MustSendGroupMsg(retMsgForm)
-}
-
 func handleAddGroup(event *cqhttp.CommonEvent) {
 	if event.SubType == cqhttp.SubTypeInvite {
 		groupId := event.GroupId
@@ -220,7 +106,7 @@ func handleAddGroup(event *cqhttp.CommonEvent) {
 		groupConfig := MustFindGroupConfig(groupId)
 		userConfig := MustFindUserConfig(userId)
 		if (groupConfig == nil || !(*groupConfig.Banned)) && (userConfig == nil || !(*userConfig.Banned)) && (userConfig.SuperAdmin != nil && *userConfig.SuperAdmin) {
-			cqhttp.MustAcceptInviteToGroup(event.Flag, event.SubType, true, "")
+			cqhttp.MustAcceptInviteToGroup(event.SelfId, event.Flag, event.SubType, true, "")
 		} else {
 			logging.L().Warn("application for joining the group was rejected",
 				logging.Any("userId", userId),
@@ -229,6 +115,17 @@ func handleAddGroup(event *cqhttp.CommonEvent) {
 	}
 }
 
+// handleGroupDecrease 群成员减少时删除成员记录，机器人被移出群时删除该群的全部记录
+func handleGroupDecrease(event *cqhttp.CommonEvent) {
+	if event.SubType == cqhttp.SubTypeKickMe || event.UserId == event.SelfId {
+		MustRemoveGroupMembers(event.GroupId)
+		MustRemovePlayerSubscriptions(event.GroupId)
+		MustRemoveBanWatches(event.GroupId)
+		return
+	}
+	MustRemoveGroupMember(event.GroupId, event.UserId)
+}
+
 func handleAddFriend(c *gin.Context, event *cqhttp.CommonEvent) {
 
 }
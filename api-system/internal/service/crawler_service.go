@@ -1,13 +1,14 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/axiangcoding/antonstar-bot/internal/data/table"
-	"github.com/axiangcoding/antonstar-bot/pkg/cqhttp"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"gorm.io/gorm"
-	"time"
 )
 
 type CrawlerResult struct {
@@ -19,8 +20,8 @@ type CrawlerResult struct {
 }
 
 type ScheduleForm struct {
-	Nick     string                  `json:"nick,omitempty"`
-	SendForm cqhttp.SendGroupMsgForm `json:"send_form"`
+	Nick     string    `json:"nick,omitempty"`
+	SendForm bot.Reply `json:"send_form"`
 }
 
 type ScheduleResult struct {
@@ -29,45 +30,77 @@ type ScheduleResult struct {
 	JobId    string `json:"jobid,omitempty"`
 }
 
-func WaitForCrawlerFinished(missionId string, fullMsg bool) error {
-	totalDelay := 60
-	duration := 3
-	i := 0
-	var detailForm ScheduleForm
-	for i <= totalDelay {
-		time.Sleep(time.Second * time.Duration(duration))
-		mission, err := FindMission(missionId)
-		if err != nil {
-			logging.L().Warn("polling find mission failed", logging.Error(err))
-			i += duration
-			continue
-		}
-		_ = json.Unmarshal([]byte(mission.Detail), &detailForm)
+var ErrCrawlerQueryFailed = errors.New("crawler query failed")
 
-		if mission.Status == table.MissionStatusSuccess {
-			user, err := FindGameProfile(detailForm.Nick)
-			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					detailForm.SendForm.Message = "未找到该用户，请检查游戏昵称是否正确"
-					break
-				}
-			} else {
-				if fullMsg {
-					detailForm.SendForm.Message = user.ToDisplayGameUser().ToFriendlyFullString()
-				} else {
-					detailForm.SendForm.Message = user.ToDisplayGameUser().ToFriendlyShortString()
-				}
-				break
-			}
-		} else if mission.Status == table.MissionStatusFailed {
-			detailForm.SendForm.Message = "查询失败，请稍后重试"
-			break
+// handleUserInfoMission 从所有数据来源爬取玩家的游戏数据，查询失败时返回错误以便重试
+func handleUserInfoMission(mission *table.Mission) (any, error) {
+	var form ScheduleForm
+	if err := json.Unmarshal([]byte(mission.Detail), &form); err != nil {
+		return nil, err
+	}
+	nickname := form.Nick
+	ctx, cancel := context.WithTimeout(context.Background(), missionLeaseDuration)
+	defer cancel()
+	fetched, err := crawler.DefaultRegistry().Fetch(ctx, nickname)
+	if err != nil {
+		return CrawlerResult{Found: false, Nick: nickname}, errors.Join(ErrCrawlerQueryFailed, err)
+	}
+	MustSaveGameUserSources(nickname, fetched.Sources)
+	if !fetched.Found {
+		MustPutRefreshFlag(nickname)
+		return CrawlerResult{Found: false, Nick: nickname}, nil
+	}
+	user := fetched.User
+	if _, err := FindGameProfile(nickname); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			MustSaveGameProfile(&user)
+		} else {
+			logging.L().Warn("find game profile failed", logging.Error(err))
 		}
-		i += duration
+	} else {
+		MustUpdateGameProfile(nickname, &user)
 	}
-	if i > totalDelay {
+	MustUpdateGameUserRating(nickname)
+	MustSaveGameUserSnapshot(nickname)
+	MustPutRefreshFlag(nickname)
+	return CrawlerResult{Found: true, Nick: nickname, Data: user}, nil
+}
+
+// WaitForCrawlerFinished 等待爬取任务结束后将玩家数据发送给用户。
+// 多个请求可能合并到同一个任务，因此每个等待者使用自己的sendForm回复
+func WaitForCrawlerFinished(missionId string, sendForm bot.Reply, fullMsg bool) error {
+	finished := WaitForMissionsFinished([]string{missionId})
+	mission, err := FindMission(missionId)
+	if err != nil {
+		return err
+	}
+	var detailForm ScheduleForm
+	_ = json.Unmarshal([]byte(mission.Detail), &detailForm)
+	detailForm.SendForm = sendForm
+	if !finished {
 		detailForm.SendForm.Message = "对不起，查询超时，请稍后重试"
+	} else if mission.Status == table.MissionStatusFailed {
+		detailForm.SendForm.Message = "查询失败，请稍后重试"
+	} else if user, err := FindGameProfile(detailForm.Nick); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		detailForm.SendForm.Message = "未找到该用户，请检查游戏昵称是否正确"
+	} else if fullMsg {
+		detailForm.SendForm.Message = toDisplayGameUser(*user).ToFriendlyFullString()
+	} else {
+		detailForm.SendForm.Message = toDisplayGameUser(*user).ToFriendlyShortString()
 	}
-	cqhttp.MustSendGroupMsg(detailForm.SendForm)
+	bot.MustSend(detailForm.SendForm)
 	return nil
 }
+
+// WaitForCompareFinished 等待缺少数据的玩家爬取完成后发送对比结果
+func WaitForCompareFinished(missionIds []string, nickA string, nickB string, sendForm bot.Reply) {
+	if !WaitForMissionsFinished(missionIds) {
+		sendForm.Message = "对不起，查询超时，请稍后重试"
+	} else {
+		fillCompareMessage(&sendForm, nickA, nickB)
+	}
+	bot.MustSend(sendForm)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/cache"
+	"github.com/axiangcoding/antonstar-bot/internal/data"
+	"os"
+	"sync"
+	"testing"
+)
+
+var (
+	testDataOnce  sync.Once
+	testCacheOnce sync.Once
+)
+
+// setupTestData 连接环境变量TEST_DB_SOURCE指定的postgres测试数据库，未设置时跳过需要数据库的测试。
+// 测试会修改库内的数据，不要指向正式环境的数据库
+func setupTestData(t *testing.T) {
+	t.Helper()
+	source := os.Getenv("TEST_DB_SOURCE")
+	if source == "" {
+		t.Skip("TEST_DB_SOURCE not set, skip test with database")
+	}
+	testDataOnce.Do(func() {
+		data.InitData(source, 5, 2)
+	})
+}
+
+// setupTestCache 连接环境变量TEST_CACHE_SOURCE指定的redis，未设置时跳过需要redis的测试
+func setupTestCache(t *testing.T) {
+	t.Helper()
+	source := os.Getenv("TEST_CACHE_SOURCE")
+	if source == "" {
+		t.Skip("TEST_CACHE_SOURCE not set, skip test with cache")
+	}
+	testCacheOnce.Do(func() {
+		cache.InitRedis(source)
+	})
+}
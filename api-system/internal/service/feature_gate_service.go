@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+)
+
+// featureGate 指令对应的群功能开关
+type featureGate struct {
+	// 功能名称，用于提示
+	name string
+	// enabled 为空时表示该指令不受群功能开关限制
+	enabled func(gc *table.QQGroupConfig) bool
+	// 停用全部功能后是否仍然可用，用于保证群主可以恢复功能
+	ignoreShutdown bool
+	// 只能在群内使用，私聊时直接提示
+	groupOnly bool
+}
+
+func isEnabled(b *bool) bool {
+	return b != nil && *b
+}
+
+// featureGates 每一个指令都必须在这里登记
+var featureGates = map[string]featureGate{
+	bot.ActionUnknown: {name: "帮助"},
+	bot.ActionQuery: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionFullQuery: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionRefresh: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionReport: {name: "举报"},
+	bot.ActionDrawCard: {name: "气运", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionLuck: {name: "气运", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionVersion:     {name: "版本"},
+	bot.ActionGetHelp:     {name: "帮助"},
+	bot.ActionGroupStatus: {name: "群状态", groupOnly: true, ignoreShutdown: true},
+	bot.ActionData:        {name: "数据"},
+	bot.ActionManager:     {name: "管理", ignoreShutdown: true},
+	bot.ActionGroupManager: {name: "配置设置", groupOnly: true, ignoreShutdown: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionSetting)
+	}},
+	bot.ActionBinding:       {name: "绑定"},
+	bot.ActionBindingVerify: {name: "绑定"},
+	bot.ActionUnbinding:     {name: "解绑"},
+	bot.ActionTrend: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionCompare: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionCardFight: {name: "气运", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionCardList: {name: "气运", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionCardRank: {name: "气运", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionCardReplay: {name: "气运", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionCardTeam: {name: "气运", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionTournament: {name: "气运", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionLuck)
+	}},
+	bot.ActionClan: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionClanRank: {name: "战绩查询", enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionLeaderboard: {name: "战绩查询", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionSubscribe: {name: "战绩查询", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionUnsubscribe: {name: "战绩查询", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionBanWatch: {name: "战绩查询", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+	bot.ActionBanUnwatch: {name: "战绩查询", groupOnly: true, enabled: func(gc *table.QQGroupConfig) bool {
+		return isEnabled(gc.EnableActionQuery)
+	}},
+}
+
+// checkFeatureGate 检查指令在群内是否可用，不可用时返回提示消息。
+// 超级管理员不受群功能开关限制，群主总是可以使用群管理来恢复功能；
+// 群管理员还需要本群开启配置设置功能，并由 DoActionGroupManager 检查是否允许管理员配置
+func checkFeatureGate(gc *table.QQGroupConfig, action *bot.Action, role string, uc *table.QQUserConfig, template int) (bool, string) {
+	if isEnabled(uc.SuperAdmin) {
+		return true, ""
+	}
+	key := bot.ActionUnknown
+	if action != nil {
+		key = action.Key
+	}
+	gate, ok := featureGates[key]
+	if !ok {
+		return true, ""
+	}
+	if isEnabled(gc.Shutdown) && !gate.ignoreShutdown {
+		return false, bot.SelectStaticMessage(template).CommonResp.GroupShutdown
+	}
+	if key == bot.ActionGroupManager && role == bot.RoleOwner {
+		return true, ""
+	}
+	if gate.enabled != nil && !gate.enabled(gc) {
+		return false, fmt.Sprintf(bot.SelectStaticMessage(template).CommonResp.FeatureDisabled, gate.name)
+	}
+	return true, ""
+}
+
+// isGroupFeatureEnabled 定时任务向群内发送消息前检查群是否被封禁、停用或关闭了指令对应的功能
+func isGroupFeatureEnabled(gc *table.QQGroupConfig, key string) bool {
+	if isEnabled(gc.Banned) || isEnabled(gc.Shutdown) {
+		return false
+	}
+	gate, ok := featureGates[key]
+	return !ok || gate.enabled == nil || gate.enabled(gc)
+}
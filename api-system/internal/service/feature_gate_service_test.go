@@ -0,0 +1,109 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestFeatureGatesCoverAllActions(t *testing.T) {
+	for _, key := range bot.Actions {
+		_, ok := featureGates[key]
+		assert.True(t, ok, "action %s has no feature gate", key)
+	}
+}
+
+func TestCheckFeatureGate(t *testing.T) {
+	trueVal := true
+	falseVal := false
+	gc := table.DefaultGroupConfig(1)
+	gc.EnableActionQuery = &falseVal
+	noAdminGc := table.DefaultGroupConfig(1)
+	noAdminGc.AllowAdminConfig = &falseVal
+	noAdminGc.EnableActionSetting = &trueVal
+	settingGc := table.DefaultGroupConfig(1)
+	settingGc.EnableActionSetting = &trueVal
+	shutdownGc := table.DefaultGroupConfig(1)
+	shutdownGc.Shutdown = &trueVal
+	uc := table.DefaultUserConfig(2)
+	superAdmin := table.DefaultUserConfig(3)
+	superAdmin.SuperAdmin = &trueVal
+
+	tests := []struct {
+		gc     table.QQGroupConfig
+		action *bot.Action
+		role   string
+		uc     table.QQUserConfig
+		ok     bool
+	}{
+		{gc: gc, action: &bot.Action{Key: bot.ActionQuery}, role: bot.RoleMember, uc: uc, ok: false},
+		{gc: gc, action: &bot.Action{Key: bot.ActionRefresh}, role: bot.RoleAdmin, uc: uc, ok: false},
+		{gc: gc, action: &bot.Action{Key: bot.ActionQuery}, role: bot.RoleMember, uc: superAdmin, ok: true},
+		{gc: gc, action: &bot.Action{Key: bot.ActionLuck}, role: bot.RoleMember, uc: uc, ok: true},
+		{gc: gc, action: nil, role: bot.RoleMember, uc: uc, ok: true},
+		// 允许管理员配置时由 DoActionGroupManager 检查，功能开关只看是否开启配置设置
+		{gc: noAdminGc, action: &bot.Action{Key: bot.ActionGroupManager}, role: bot.RoleAdmin, uc: uc, ok: true},
+		{gc: gc, action: &bot.Action{Key: bot.ActionGroupManager}, role: bot.RoleAdmin, uc: uc, ok: false},
+		{gc: settingGc, action: &bot.Action{Key: bot.ActionGroupManager}, role: bot.RoleAdmin, uc: uc, ok: true},
+		{gc: gc, action: &bot.Action{Key: bot.ActionGroupManager}, role: bot.RoleMember, uc: uc, ok: false},
+		{gc: gc, action: &bot.Action{Key: bot.ActionGroupManager}, role: bot.RoleOwner, uc: uc, ok: true},
+		{gc: shutdownGc, action: &bot.Action{Key: bot.ActionLuck}, role: bot.RoleMember, uc: uc, ok: false},
+		{gc: shutdownGc, action: &bot.Action{Key: bot.ActionGroupStatus}, role: bot.RoleMember, uc: uc, ok: true},
+		{gc: shutdownGc, action: &bot.Action{Key: bot.ActionGroupManager}, role: bot.RoleOwner, uc: uc, ok: true},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ok, reply := checkFeatureGate(&tt.gc, tt.action, tt.role, &tt.uc, 0)
+			assert.Equal(t, tt.ok, ok)
+			if !ok {
+				assert.NotEmpty(t, reply)
+			}
+		})
+	}
+}
+
+func TestIsGroupFeatureEnabled(t *testing.T) {
+	trueVal := true
+	falseVal := false
+	gc := table.DefaultGroupConfig(1)
+	gc.EnableActionLuck = &trueVal
+	luckOff := table.DefaultGroupConfig(1)
+	luckOff.EnableActionLuck = &falseVal
+	banned := gc
+	banned.Banned = &trueVal
+	shutdown := gc
+	shutdown.Shutdown = &trueVal
+
+	tests := []struct {
+		gc   table.QQGroupConfig
+		key  string
+		want bool
+	}{
+		{gc: gc, key: bot.ActionTournament, want: true},
+		{gc: luckOff, key: bot.ActionTournament, want: false},
+		{gc: luckOff, key: bot.ActionVersion, want: true},
+		{gc: banned, key: bot.ActionTournament, want: false},
+		{gc: shutdown, key: bot.ActionTournament, want: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, isGroupFeatureEnabled(&tt.gc, tt.key))
+		})
+	}
+}
+
+func TestDoActionGroupOnly(t *testing.T) {
+	uc := table.DefaultUserConfig(2)
+	for key, gate := range featureGates {
+		if !gate.groupOnly {
+			continue
+		}
+		t.Run(key, func(t *testing.T) {
+			reply := bot.Reply{MessageType: bot.MessageTypePrivate, UserId: 2}
+			doAction(&reply, &bot.Action{Key: key}, &uc, "")
+			assert.Equal(t, bot.SelectStaticMessage(0).CommonResp.OnlyInGroup, reply.Message)
+		})
+	}
+}
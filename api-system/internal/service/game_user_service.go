@@ -5,9 +5,13 @@ import (
 	"errors"
 	"github.com/axiangcoding/antonstar-bot/internal/cache"
 	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
 	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/crawler"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"github.com/go-redis/redis/v8"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
 	"regexp"
 	"time"
 )
@@ -34,8 +38,15 @@ func MustSaveGameProfile(gameUser *table.GameUser) {
 	}
 }
 
+// MustUpdateGameProfile 用爬取的资料覆盖玩家资料。更新所有字段，退出联队、清空称号等零值也会写入。
+// 安东星效率值由 MustUpdateGameUserRating 单独计算；thunderskill没有取得数据时保留上次的效率值
 func MustUpdateGameProfile(nick string, user *table.GameUser) {
-	if _, err := dal.GameUser.Where(dal.GameUser.Nick.Eq(nick)).Updates(user); err != nil {
+	gu := dal.GameUser
+	omits := []field.Expr{gu.ID, gu.CreatedAt, gu.AsABRate, gu.AsRBRate, gu.AsSBRate}
+	if user.TsABRate == 0 && user.TsRBRate == 0 && user.TsSBRate == 0 {
+		omits = append(omits, gu.TsABRate, gu.TsRBRate, gu.TsSBRate)
+	}
+	if _, err := gu.Where(gu.Nick.Eq(nick)).Select(field.Star).Omit(omits...).Updates(user); err != nil {
 		logging.L().Error("dal error", logging.Error(err))
 	}
 }
@@ -60,3 +71,96 @@ func MustPutRefreshFlag(nick string) {
 		logging.L().Error("set cache error", logging.Error(err))
 	}
 }
+
+var ErrNotEnoughSnapshot = errors.New("not enough game user snapshot")
+
+// MustSaveGameUserSnapshot 保存玩家当前数据的快照，在每次成功爬取后调用
+func MustSaveGameUserSnapshot(nick string) {
+	profile, err := FindGameProfile(nick)
+	if err != nil {
+		logging.L().Warn("find game profile failed", logging.Error(err))
+		return
+	}
+	snapshot := table.NewGameUserSnapshot(*profile)
+	if err := dal.GameUserSnapshot.Save(&snapshot); err != nil {
+		logging.L().Error("dal error", logging.Error(err))
+	}
+}
+
+// FindProfileTrend 计算最近days天内玩家数据的变化，以days天前最近的一份快照为基准，
+// 没有时以区间内最早的一份为基准，与最新的快照进行比较
+func FindProfileTrend(nick string, days int) (*display.ProfileTrend, error) {
+	gus := dal.GameUserSnapshot
+	since := time.Now().AddDate(0, 0, -days)
+	var candidates []table.GameUserSnapshot
+	for _, do := range []dal.IGameUserSnapshotDo{
+		gus.Where(gus.Nick.Eq(nick), gus.CreatedAt.Lte(since)).Order(gus.CreatedAt.Desc()),
+		gus.Where(gus.Nick.Eq(nick), gus.CreatedAt.Gt(since)).Order(gus.CreatedAt),
+		gus.Where(gus.Nick.Eq(nick)).Order(gus.CreatedAt.Desc()),
+	} {
+		found, err := do.Limit(1).Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range found {
+			candidates = append(candidates, *snapshot)
+		}
+	}
+	base, last, ok := table.SelectTrendSnapshots(candidates, since)
+	if !ok {
+		return nil, ErrNotEnoughSnapshot
+	}
+	trend := base.ToDisplayTrend(last)
+	return &trend, nil
+}
+
+// CompareGameProfiles 对比两个玩家的数据，有玩家没有数据时返回其昵称
+func CompareGameProfiles(nickA string, nickB string) (*display.ProfileCompare, []string, error) {
+	var users []table.GameUser
+	var missing []string
+	for _, nick := range []string{nickA, nickB} {
+		user, err := FindGameProfile(nick)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				missing = append(missing, nick)
+				continue
+			}
+			return nil, nil, err
+		}
+		users = append(users, *user)
+	}
+	if len(missing) > 0 {
+		return nil, missing, nil
+	}
+	compare := table.CompareGameUser(users[0], users[1])
+	return &compare, nil, nil
+}
+
+// MustSaveGameUserSources 记录玩家数据在每个来源的获取情况
+func MustSaveGameUserSources(nick string, statuses []crawler.SourceStatus) {
+	gus := dal.GameUserSource
+	for _, status := range statuses {
+		source, err := gus.Where(gus.Nick.Eq(nick), gus.Source.Eq(status.Name)).
+			FirstOrCreate()
+		if err != nil {
+			logging.L().Error("dal error", logging.Error(err))
+			continue
+		}
+		if status.Err != nil {
+			source.LastError = status.Err.Error()
+		} else {
+			source.Found = status.Found
+			source.FetchedAt = status.FetchedAt
+			source.LastError = ""
+		}
+		if err := gus.Save(source); err != nil {
+			logging.L().Error("dal error", logging.Error(err))
+		}
+	}
+}
+
+// FindGameUserSources 玩家数据在每个来源最近一次的获取情况
+func FindGameUserSources(nick string) ([]*table.GameUserSource, error) {
+	gus := dal.GameUserSource
+	return gus.Where(gus.Nick.Eq(nick)).Order(gus.Source).Find()
+}
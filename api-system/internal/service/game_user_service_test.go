@@ -0,0 +1,86 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFindProfileTrend(t *testing.T) {
+	setupTestData(t)
+	gus := dal.GameUserSnapshot
+	nick := "trend_test_" + time.Now().Format("150405.000")
+	t.Cleanup(func() {
+		_, _ = gus.Unscoped().Where(gus.Nick.Eq(nick)).Delete()
+	})
+
+	_, err := FindProfileTrend(nick, 7)
+	assert.ErrorIs(t, err, ErrNotEnoughSnapshot)
+
+	now := time.Now()
+	old := table.GameUserSnapshot{Nick: nick, Level: 50, StatRb: table.UserStat{TotalMission: 100}}
+	old.CreatedAt = now.AddDate(0, 0, -8)
+	assert.NoError(t, gus.Create(&old))
+	_, err = FindProfileTrend(nick, 7)
+	assert.ErrorIs(t, err, ErrNotEnoughSnapshot)
+
+	// 8天前和今天各爬取一次时，以8天前的快照为基准
+	latest := table.GameUserSnapshot{Nick: nick, Level: 52, StatRb: table.UserStat{TotalMission: 130}}
+	latest.CreatedAt = now
+	assert.NoError(t, gus.Create(&latest))
+	trend, err := FindProfileTrend(nick, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, trend.LevelDelta)
+	assert.Equal(t, 30, trend.Rb.MissionDelta)
+
+	// 更早的快照不影响基准
+	older := table.GameUserSnapshot{Nick: nick, Level: 40}
+	older.CreatedAt = now.AddDate(0, 0, -20)
+	assert.NoError(t, gus.Create(&older))
+	trend, err = FindProfileTrend(nick, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, trend.LevelDelta)
+}
+
+func TestMustUpdateGameProfile(t *testing.T) {
+	setupTestData(t)
+	gu := dal.GameUser
+	nick := "update_test_" + time.Now().Format("150405.000")
+	t.Cleanup(func() {
+		_, _ = gu.Unscoped().Where(gu.Nick.Eq(nick)).Delete()
+	})
+
+	banned := true
+	user := table.GameUser{Nick: nick, Clan: "-RB-", ClanUrl: "https://warthunder.com/zh/community/claninfo/Rock%20Band",
+		Title: "坦克杀手", Level: 100, Banned: &banned, TsRBRate: 84.72, AsRBRate: 1.2}
+	MustSaveGameProfile(&user)
+	created, err := gu.Where(gu.Nick.Eq(nick)).Take()
+	assert.NoError(t, err)
+
+	// 退出联队、清空称号、解封后重新爬取的资料
+	notBanned := false
+	MustUpdateGameProfile(nick, &table.GameUser{Nick: nick, Level: 101, Banned: &notBanned})
+	got, err := gu.Where(gu.Nick.Eq(nick)).Take()
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+	assert.Equal(t, created.CreatedAt.Unix(), got.CreatedAt.Unix())
+	assert.Empty(t, got.Clan)
+	assert.Empty(t, got.ClanUrl)
+	assert.Empty(t, got.Title)
+	assert.Equal(t, 101, got.Level)
+	if assert.NotNil(t, got.Banned) {
+		assert.False(t, *got.Banned)
+	}
+	// thunderskill没有取得数据时不覆盖效率值
+	assert.Equal(t, 84.72, got.TsRBRate)
+	assert.Equal(t, 1.2, got.AsRBRate)
+
+	MustUpdateGameProfile(nick, &table.GameUser{Nick: nick, Level: 101, Banned: &notBanned, TsABRate: 82.48})
+	got, err = gu.Where(gu.Nick.Eq(nick)).Take()
+	assert.NoError(t, err)
+	assert.Equal(t, 82.48, got.TsABRate)
+	assert.Zero(t, got.TsRBRate)
+	assert.Equal(t, 1.2, got.AsRBRate)
+}
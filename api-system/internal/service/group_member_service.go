@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"github.com/axiangcoding/antonstar-bot/internal/cache"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+const (
+	// groupMemberRecordInterval 同一个群成员在该时间内只记录一次
+	groupMemberRecordInterval = time.Hour * 24
+	// groupLeaderboardSize 机器人回复中展示的排行人数
+	groupLeaderboardSize = 10
+)
+
+// MustRecordGroupMember 记录在群内发言的成员
+func MustRecordGroupMember(groupId int64, userId int64) {
+	key := cache.GenerateGroupMemberCacheKey(groupId, userId)
+	ok, err := cache.Client().SetNX(context.Background(), key, "", groupMemberRecordInterval).Result()
+	if err != nil {
+		logging.L().Error("set cache error", logging.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+	member := table.QQGroupMember{
+		GroupId:    groupId,
+		UserId:     userId,
+		LastSeenAt: time.Now(),
+	}
+	gm := dal.QQGroupMember
+	if err := gm.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "group_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_seen_at", "updated_at"}),
+	}).Create(&member); err != nil {
+		logging.L().Error("dal failed", logging.Error(err))
+	}
+}
+
+// MustRemoveGroupMember 成员退群后不再参与群排行
+func MustRemoveGroupMember(groupId int64, userId int64) {
+	gm := dal.QQGroupMember
+	if _, err := gm.Unscoped().Where(gm.GroupId.Eq(groupId), gm.UserId.Eq(userId)).Delete(); err != nil {
+		logging.L().Error("dal failed", logging.Error(err))
+	}
+	if err := cache.Client().Del(context.Background(), cache.GenerateGroupMemberCacheKey(groupId, userId)).Err(); err != nil {
+		logging.L().Error("delete cache error", logging.Error(err))
+	}
+}
+
+// MustRemoveGroupMembers 机器人退群后清空该群的成员记录
+func MustRemoveGroupMembers(groupId int64) {
+	gm := dal.QQGroupMember
+	if _, err := gm.Unscoped().Where(gm.GroupId.Eq(groupId)).Delete(); err != nil {
+		logging.L().Error("dal failed", logging.Error(err))
+	}
+}
+
+// findGroupBindingNicks 群内成员绑定的游戏昵称
+func findGroupBindingNicks(groupId int64) ([]string, error) {
+	gm := dal.QQGroupMember
+	members, err := gm.Where(gm.GroupId.Eq(groupId)).Find()
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	userIds := make([]int64, len(members))
+	for i, member := range members {
+		userIds[i] = member.UserId
+	}
+	uc := dal.QQUserConfig
+	configs, err := uc.Where(uc.UserId.In(userIds...), uc.BindingGameNick.IsNotNull(), uc.BindingGameNick.Neq("")).Find()
+	if err != nil {
+		return nil, err
+	}
+	var nicks []string
+	for _, config := range configs {
+		nicks = append(nicks, *config.BindingGameNick)
+	}
+	return nicks, nil
+}
+
+// FindGroupLeaderboard 按指定模式和指标对群内绑定了游戏昵称的成员排行
+func FindGroupLeaderboard(groupId int64, metric string, mode string, offset int, limit int) (*display.Leaderboard, error) {
+	nicks, err := findGroupBindingNicks(groupId)
+	if err != nil {
+		return nil, err
+	}
+	leaderboard := table.NewLeaderboard(metric, mode)
+	if len(nicks) > 0 {
+		gu := dal.GameUser
+		users, err := gu.Where(gu.Nick.In(nicks...)).Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			leaderboard.Add(*user)
+		}
+	}
+	ret := leaderboard.ToDisplay(offset, limit)
+	return &ret, nil
+}
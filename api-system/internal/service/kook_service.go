@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/kook"
+)
+
+func HandleKookEvent(payload *kook.WebhookPayload) error {
+	event := payload.D
+	if !event.IsUserMessage() {
+		return nil
+	}
+	msg, err := kookToBotMessage(event)
+	if err != nil {
+		return err
+	}
+	HandleBotMessage(msg)
+	return nil
+}
+
+// kookToBotMessage 注册了kook适配器时由适配器补充发送者在服务器内的角色
+func kookToBotMessage(event kook.EventData) (bot.Message, error) {
+	if adapter, ok := bot.GetAdapter(bot.PlatformKook); ok {
+		if kookAdapter, ok := adapter.(*kook.Adapter); ok {
+			return kookAdapter.ToBotMessage(event)
+		}
+	}
+	return event.ToBotMessage()
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/axiangcoding/antonstar-bot/setting"
+	"gorm.io/gorm"
+)
+
+// HandleBotMessage 处理各平台适配器转换后的消息
+func HandleBotMessage(msg bot.Message) {
+	// 所有群消息都用于记录群成员，不只是指令
+	if msg.MessageType == bot.MessageTypeGroup {
+		MustRecordGroupMember(msg.GroupId, msg.UserId)
+	}
+	if !bot.ContainsTrigger(msg.Content) {
+		return
+	}
+	switch msg.MessageType {
+	case bot.MessageTypeGroup:
+		handleGroupMessage(msg)
+	case bot.MessageTypePrivate:
+		handlePrivateMessage(msg)
+	default:
+		logging.L().Warn("message_type not supported yet",
+			logging.Any("platform", msg.Platform),
+			logging.Any("message_type", msg.MessageType))
+	}
+}
+
+func findOrCreateUserConfig(userId int64) (*table.QQUserConfig, error) {
+	uc, err := FindUserConfig(userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			defaultUC := table.DefaultUserConfig(userId)
+			uc = &defaultUC
+			MustSaveUserConfig(uc)
+		} else {
+			return nil, err
+		}
+	}
+	return uc, nil
+}
+
+func findOrCreateGroupConfig(groupId int64) (*table.QQGroupConfig, error) {
+	gc, err := FindGroupConfig(groupId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			defaultGC := table.DefaultGroupConfig(groupId)
+			gc = &defaultGC
+			MustSaveGroupConfig(gc)
+		} else {
+			return nil, err
+		}
+	}
+	return gc, nil
+}
+
+func handleGroupMessage(msg bot.Message) {
+	action := bot.ParseMessageCommand(msg.Content)
+	stopAllResponse := IsStopAllResponse()
+	if stopAllResponse && (action == nil || action.Key != bot.ActionManager) {
+		return
+	}
+	groupId := msg.GroupId
+	userId := msg.UserId
+	gc, err := findOrCreateGroupConfig(groupId)
+	if err != nil {
+		logging.L().Warn("find group config failed", logging.Error(err))
+		return
+	}
+	uc, err := findOrCreateUserConfig(userId)
+	if err != nil {
+		logging.L().Warn("find user config failed", logging.Error(err))
+		return
+	}
+	retMsgForm := msg.NewReply()
+	retMsgForm.MessageTemplate = gc.MessageTemplate
+	// 检查qq群请求限制
+	if limit, usage, total := CheckGroupTodayUsageLimit(groupId); limit {
+		if !ExistGroupUsageLimitFlag(groupId) {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayGroupUsageLimit, usage, total)
+			bot.MustSend(retMsgForm)
+			MustPutGroupUsageLimitFlag(groupId)
+		}
+		return
+	}
+	// 检查qq请求限制
+	if limit, usage, total := CheckUserTodayUsageLimit(userId); limit {
+		if !ExistUserUsageLimitFlag(userId) {
+			retMsgForm.MessagePrefix = bot.Mention(msg.Platform, userId)
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayUserUsageLimit, usage, total)
+			bot.MustSend(retMsgForm)
+			MustPutUserUsageLimitFlag(userId)
+		}
+		return
+	}
+	MustAddGroupConfigTodayUsageCount(groupId, 1)
+	MustAddGroupConfigTotalUsageCount(groupId, 1)
+	MustAddUserConfigTodayUsageCount(userId, 1)
+	MustAddUserConfigTotalUsageCount(userId, 1)
+
+	if *gc.Banned {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GroupGetBanned
+		bot.MustSend(retMsgForm)
+		return
+	}
+	retMsgForm.MessagePrefix = bot.Mention(msg.Platform, userId)
+	if *uc.Banned {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.UserGetBanned
+		bot.MustSend(retMsgForm)
+		return
+	}
+	// 检查群功能开关，放在封禁和使用次数检查之后，被关闭的功能同样计入使用次数
+	if ok, reply := checkFeatureGate(gc, action, msg.Role, uc, retMsgForm.MessageTemplate); !ok {
+		retMsgForm.Message = reply
+		bot.MustSend(retMsgForm)
+		return
+	}
+	doAction(&retMsgForm, action, uc, msg.Role)
+	bot.MustSend(retMsgForm)
+}
+
+// handlePrivateMessage 处理私聊消息，与群聊共用指令，但只受个人配置的限制
+func handlePrivateMessage(msg bot.Message) {
+	action := bot.ParseMessageCommand(msg.Content)
+	stopAllResponse := IsStopAllResponse()
+	if stopAllResponse && (action == nil || action.Key != bot.ActionManager) {
+		return
+	}
+	userId := msg.UserId
+	uc, err := findOrCreateUserConfig(userId)
+	if err != nil {
+		logging.L().Warn("find user config failed", logging.Error(err))
+		return
+	}
+	retMsgForm := msg.NewReply()
+	// 检查qq请求限制
+	if limit, usage, total := CheckUserTodayUsageLimit(userId); limit {
+		if !ExistUserUsageLimitFlag(userId) {
+			retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.TodayUserUsageLimit, usage, total)
+			bot.MustSend(retMsgForm)
+			MustPutUserUsageLimitFlag(userId)
+		}
+		return
+	}
+	MustAddUserConfigTodayUsageCount(userId, 1)
+	MustAddUserConfigTotalUsageCount(userId, 1)
+
+	if *uc.Banned {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.UserGetBanned
+		bot.MustSend(retMsgForm)
+		return
+	}
+	doAction(&retMsgForm, action, uc, msg.Role)
+	bot.MustSend(retMsgForm)
+}
+
+// doAction 执行指令，并将回复内容写入retMsgForm，role为发送者在群内的角色
+func doAction(retMsgForm *bot.Reply, action *bot.Action, uc *table.QQUserConfig, role string) {
+	if action == nil {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Common
+		return
+	}
+	if retMsgForm.IsPrivate() && featureGates[action.Key].groupOnly {
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.OnlyInGroup
+		return
+	}
+	value := action.Value
+	switch action.Key {
+	case bot.ActionQuery:
+		DoActionQuery(retMsgForm, value, false)
+	case bot.ActionFullQuery:
+		DoActionQuery(retMsgForm, value, true)
+	case bot.ActionRefresh:
+		DoActionRefresh(retMsgForm, value)
+	case bot.ActionReport:
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Report
+	case bot.ActionDrawCard:
+		DoActionDrawCard(retMsgForm, value, retMsgForm.UserId)
+	case bot.ActionLuck:
+		DoActionLuck(retMsgForm, value, retMsgForm.UserId)
+	case bot.ActionVersion:
+		retMsgForm.Message = fmt.Sprintf(bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.Version, setting.C().App.Version)
+	case bot.ActionGetHelp:
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GetHelp
+	case bot.ActionGroupStatus:
+		DoActionGroupStatus(retMsgForm)
+	case bot.ActionGroupManager:
+		DoActionGroupManager(retMsgForm, uc, role, value)
+	case bot.ActionData:
+		DoActionData(retMsgForm, value)
+	case bot.ActionBinding:
+		DoActionBinding(retMsgForm, value)
+	case bot.ActionBindingVerify:
+		DoActionBindingVerify(retMsgForm)
+	case bot.ActionUnbinding:
+		DoActionUnbinding(retMsgForm)
+	case bot.ActionManager:
+		DoActionManager(retMsgForm, uc, value)
+	case bot.ActionTrend:
+		DoActionTrend(retMsgForm, value)
+	case bot.ActionCompare:
+		DoActionCompare(retMsgForm, value)
+	case bot.ActionCardFight:
+		DoActionCardFight(retMsgForm, value)
+	case bot.ActionCardList:
+		DoActionCardList(retMsgForm)
+	case bot.ActionCardRank:
+		DoActionCardRank(retMsgForm)
+	case bot.ActionCardReplay:
+		DoActionCardReplay(retMsgForm, value)
+	case bot.ActionCardTeam:
+		DoActionCardTeamFight(retMsgForm, value)
+	case bot.ActionTournament:
+		DoActionTournament(retMsgForm, uc, role, value)
+	case bot.ActionClan:
+		DoActionClan(retMsgForm, value)
+	case bot.ActionClanRank:
+		DoActionClanRank(retMsgForm)
+	case bot.ActionLeaderboard:
+		DoActionLeaderboard(retMsgForm, value)
+	case bot.ActionSubscribe:
+		DoActionSubscribe(retMsgForm)
+	case bot.ActionUnsubscribe:
+		DoActionUnsubscribe(retMsgForm)
+	case bot.ActionBanWatch:
+		DoActionBanWatch(retMsgForm, value)
+	case bot.ActionBanUnwatch:
+		DoActionBanUnwatch(retMsgForm, value)
+	default:
+		retMsgForm.Message = bot.SelectStaticMessage(retMsgForm.MessageTemplate).CommonResp.GetHelp
+	}
+}
@@ -1,13 +1,55 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/internal/cache"
 	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
 	"github.com/axiangcoding/antonstar-bot/internal/data/table"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"os"
 	"time"
 )
 
+const (
+	// missionLeaseDuration worker领取任务后的租约时长，执行期间会定期续约
+	missionLeaseDuration = 2 * time.Minute
+	// missionPollInterval 没有可执行的任务时，worker重新检查的间隔
+	missionPollInterval = 3 * time.Second
+	// missionRetryBaseDelay 任务失败后第一次重试的延迟，之后每次翻倍
+	missionRetryBaseDelay = 10 * time.Second
+	missionRetryMaxDelay  = 10 * time.Minute
+	// missionStaleDuration 启动时超过该时长仍未结束的任务不再恢复执行
+	missionStaleDuration = 24 * time.Hour
+	// missionWaitTimeout 等待任务结束的最长时间
+	missionWaitTimeout = 60 * time.Second
+	// missionInflightExpire 合并任务的标记的过期时间，任务异常未能清除标记时以此兜底
+	missionInflightExpire = 10 * time.Minute
+
+	defaultMissionWorkers     = 4
+	defaultMissionMaxAttempts = 3
+)
+
+// MissionHandler 执行一种类型的任务，返回的结果会保存在任务中。返回错误时任务会延迟重试
+type MissionHandler func(mission *table.Mission) (any, error)
+
+var missionHandlers = map[string]MissionHandler{
+	table.MissionTypeUserInfo: handleUserInfoMission,
+	table.MissionTypeClan:     handleClanMission,
+}
+
+var (
+	missionMaxAttempts = defaultMissionMaxAttempts
+	// missionQueued 提交任务后唤醒空闲的worker
+	missionQueued = make(chan struct{}, 1)
+)
+
 func FindMission(missionId string) (*table.Mission, error) {
 	take, err := dal.Q.Mission.Where(dal.Mission.MissionId.Eq(missionId)).Take()
 	if err != nil {
@@ -16,7 +58,12 @@ func FindMission(missionId string) (*table.Mission, error) {
 	return take, nil
 }
 
+// SubmitMissionWithDetail 提交一个待执行的任务，任务会由worker领取执行
 func SubmitMissionWithDetail(missionId string, missionType string, detail any) error {
+	return submitMission(missionId, missionType, "", detail)
+}
+
+func submitMission(missionId string, missionType string, dedupKey string, detail any) error {
 	bytes, err := json.Marshal(detail)
 	if err != nil {
 		return err
@@ -27,24 +74,301 @@ func SubmitMissionWithDetail(missionId string, missionType string, detail any) e
 		Status:    table.MissionStatusPending,
 		Process:   0,
 		Detail:    string(bytes),
+		NextRunAt: time.Now(),
+		DedupKey:  dedupKey,
 	}
 	if err := dal.Q.Mission.Save(&mission); err != nil {
 		return err
 	}
+	select {
+	case missionQueued <- struct{}{}:
+	default:
+	}
 	return nil
 }
 
-func MustFinishMissionWithResult(missionId string, status string, result any) {
+// releaseInflightScript 只删除仍然指向该任务的标记，避免误删之后提交的任务的标记
+var releaseInflightScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+// SubmitDedupMission 提交一个可以合并的任务。相同类型和dedupKey的任务正在执行时不再重复提交，
+// 直接返回正在执行的任务id，跨进程的合并通过redis实现。submitted表示是否提交了新的任务
+func SubmitDedupMission(missionType string, dedupKey string, detail any) (missionId string, submitted bool, err error) {
+	ctx := context.Background()
+	key := cache.GenerateMissionInflightCacheKey(missionType, dedupKey)
+	for i := 0; i < 2; i++ {
+		missionId = uuid.NewString()
+		ok, err := cache.Client().SetNX(ctx, key, missionId, missionInflightExpire).Result()
+		if err != nil {
+			return "", false, err
+		}
+		if ok {
+			if err := submitMission(missionId, missionType, dedupKey, detail); err != nil {
+				releaseInflightScript.Run(ctx, cache.Client(), []string{key}, missionId)
+				return "", false, err
+			}
+			return missionId, true, nil
+		}
+		inflightId, err := cache.Client().Get(ctx, key).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return "", false, err
+		}
+		if inflightId != "" {
+			mission, err := FindMission(inflightId)
+			if err == nil && !isMissionFinished(mission) {
+				return inflightId, false, nil
+			}
+			// 标记指向的任务已经结束，清除后重新提交
+			releaseInflightScript.Run(ctx, cache.Client(), []string{key}, inflightId)
+		}
+	}
+	return "", false, errors.New("submit dedup mission conflict")
+}
+
+func mustReleaseInflightMission(mission *table.Mission) {
+	if mission.DedupKey == "" {
+		return
+	}
+	key := cache.GenerateMissionInflightCacheKey(mission.Type, mission.DedupKey)
+	if err := releaseInflightScript.Run(context.Background(), cache.Client(), []string{key}, mission.MissionId).Err(); err != nil &&
+		!errors.Is(err, redis.Nil) {
+		logging.L().Warn("release inflight mission failed", logging.Error(err))
+	}
+}
+
+// StartMissionWorkers 启动执行任务的worker。上次退出时未完成的任务会在租约过期后被重新领取。
+// 注意等待任务结果的回复只保存在进程内，重启后恢复执行的任务只会保存结果，不会再回复当时发起请求的用户
+func StartMissionWorkers(workers int, maxAttempts int) {
+	if workers <= 0 {
+		workers = defaultMissionWorkers
+	}
+	if maxAttempts > 0 {
+		missionMaxAttempts = maxAttempts
+	}
+	if count, err := expireStaleMissions(); err != nil {
+		logging.L().Warn("expire stale missions failed", logging.Error(err))
+	} else if count > 0 {
+		logging.L().Info("expire stale missions", logging.Any("count", count))
+	}
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+	for i := 0; i < workers; i++ {
+		go runMissionWorker(fmt.Sprintf("%s-%d", owner, i))
+	}
+	logging.L().Info("mission workers started", logging.Any("workers", workers))
+}
+
+// expireStaleMissions 长时间未完成的任务已经没有人在等待结果，直接标记为失败
+func expireStaleMissions() (int64, error) {
+	m := dal.Mission
+	info, err := m.Where(m.Status.In(table.MissionStatusPending, table.MissionStatusRunning),
+		m.CreatedAt.Lt(time.Now().Add(-missionStaleDuration))).
+		Updates(table.Mission{
+			Status:       table.MissionStatusFailed,
+			FinishedTime: time.Now(),
+			LastError:    "mission expired",
+		})
+	return info.RowsAffected, err
+}
+
+func runMissionWorker(owner string) {
+	for {
+		mission, err := ClaimMission(owner)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				logging.L().Warn("claim mission failed", logging.Error(err))
+			}
+			select {
+			case <-missionQueued:
+			case <-time.After(missionPollInterval):
+			}
+			continue
+		}
+		runMission(mission, owner)
+	}
+}
+
+// ClaimMission 领取一个到期的待执行任务或者租约已经过期的执行中任务，没有任务时返回gorm.ErrRecordNotFound
+func ClaimMission(owner string) (*table.Mission, error) {
+	var claimed *table.Mission
+	err := dal.Q.Transaction(func(tx *dal.Query) error {
+		m := tx.Mission
+		now := time.Now()
+		mission, err := m.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(m.Where(m.Status.Eq(table.MissionStatusPending), m.NextRunAt.Lte(now)).
+				Or(m.Status.Eq(table.MissionStatusRunning), m.LeaseUntil.Lt(now))).
+			Order(m.NextRunAt, m.ID).
+			First()
+		if err != nil {
+			return err
+		}
+		mission.Status = table.MissionStatusRunning
+		mission.Attempts++
+		mission.LeaseOwner = owner
+		mission.LeaseUntil = now.Add(missionLeaseDuration)
+		if err := m.Save(mission); err != nil {
+			return err
+		}
+		claimed = mission
+		return nil
+	})
+	return claimed, err
+}
+
+func runMission(mission *table.Mission, owner string) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(missionLeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				mustRenewMissionLease(mission.MissionId, owner)
+			}
+		}
+	}()
+
+	handler, ok := missionHandlers[mission.Type]
+	if !ok {
+		mission.Attempts = missionMaxAttempts
+		mustFinishMission(mission, owner, nil, fmt.Errorf("unknown mission type %s", mission.Type))
+		return
+	}
+	result, err := callMissionHandler(handler, mission)
+	mustFinishMission(mission, owner, result, err)
+}
+
+func callMissionHandler(handler MissionHandler, mission *table.Mission) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("mission panic: %v", r)
+		}
+	}()
+	return handler(mission)
+}
+
+func mustRenewMissionLease(missionId string, owner string) {
+	m := dal.Mission
+	if _, err := m.Where(m.MissionId.Eq(missionId), m.LeaseOwner.Eq(owner)).
+		Update(m.LeaseUntil, time.Now().Add(missionLeaseDuration)); err != nil {
+		logging.L().Warn("renew mission lease failed", logging.Error(err))
+	}
+}
+
+// missionFinishUpdate 计算任务执行结束后需要更新的字段。失败且未达到最大执行次数时，任务会回到待执行状态并延迟重试，
+// finished表示任务是否已经结束
+func missionFinishUpdate(attempts int, result any, err error, now time.Time) (update map[string]any, finished bool) {
 	bytes, _ := json.Marshal(result)
-	update := table.Mission{
-		Status:       status,
-		Process:      100,
-		Result:       string(bytes),
-		FinishedTime: time.Now(),
+	update = map[string]any{
+		"result":      string(bytes),
+		"lease_owner": "",
+		"lease_until": time.Time{},
+	}
+	if err == nil {
+		update["status"] = table.MissionStatusSuccess
+		update["process"] = 100
+		update["finished_time"] = now
+		return update, true
+	}
+	update["last_error"] = err.Error()
+	if attempts < missionMaxAttempts {
+		update["status"] = table.MissionStatusPending
+		update["next_run_at"] = now.Add(missionRetryDelay(attempts))
+		return update, false
+	}
+	update["status"] = table.MissionStatusFailed
+	update["process"] = 100
+	update["finished_time"] = now
+	return update, true
+}
+
+// mustFinishMission 保存任务的执行结果，任务结束后通过redis发布通知
+func mustFinishMission(mission *table.Mission, owner string, result any, err error) {
+	update, finished := missionFinishUpdate(mission.Attempts, result, err, time.Now())
+	if err != nil {
+		logging.L().Warn("mission failed",
+			logging.Error(err),
+			logging.Any("missionId", mission.MissionId),
+			logging.Any("attempts", mission.Attempts))
+	}
+
+	m := dal.Mission
+	info, dbErr := m.Where(m.MissionId.Eq(mission.MissionId), m.LeaseOwner.Eq(owner)).Updates(update)
+	if dbErr != nil {
+		logging.L().Warn("dal failed", logging.Error(dbErr))
+		return
+	}
+	// 租约已经被其他worker领取，由其他worker负责结束任务
+	if info.RowsAffected == 0 {
+		logging.L().Warn("mission lease lost", logging.Any("missionId", mission.MissionId))
+		return
+	}
+	if finished {
+		mustReleaseInflightMission(mission)
+		if err := cache.Client().Publish(context.Background(),
+			cache.GenerateMissionFinishedChannel(), mission.MissionId).Err(); err != nil {
+			logging.L().Warn("publish mission finished failed", logging.Error(err))
+		}
+	}
+}
+
+// missionRetryDelay 第attempts次执行失败后，距离下一次重试的延迟
+func missionRetryDelay(attempts int) time.Duration {
+	delay := missionRetryBaseDelay
+	for i := 1; i < attempts && delay < missionRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > missionRetryMaxDelay {
+		return missionRetryMaxDelay
+	}
+	return delay
+}
+
+func isMissionFinished(mission *table.Mission) bool {
+	return mission.Status == table.MissionStatusSuccess || mission.Status == table.MissionStatusFailed
+}
+
+// WaitForMissionsFinished 等待全部任务结束，超时返回false。任务结束的通知通过redis订阅获取。
+// 等待者只存在于当前进程，进程退出后不会再收到通知
+func WaitForMissionsFinished(missionIds []string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), missionWaitTimeout)
+	defer cancel()
+	sub := cache.Client().Subscribe(ctx, cache.GenerateMissionFinishedChannel())
+	defer sub.Close()
+	// 确认订阅成功后再检查任务状态，避免错过在此期间结束的任务
+	if _, err := sub.Receive(ctx); err != nil {
+		logging.L().Warn("subscribe mission finished failed", logging.Error(err))
+		return false
+	}
+	remain := make(map[string]bool)
+	for _, missionId := range missionIds {
+		mission, err := FindMission(missionId)
+		if err != nil {
+			logging.L().Warn("find mission failed", logging.Error(err))
+			return false
+		}
+		if !isMissionFinished(mission) {
+			remain[missionId] = true
+		}
 	}
-	if _, err := dal.Q.Mission.
-		Where(dal.Mission.MissionId.Eq(missionId)).
-		Updates(update); err != nil {
-		logging.L().Warn("dal failed", logging.Error(err))
+	ch := sub.Channel()
+	for len(remain) > 0 {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return false
+			}
+			delete(remain, msg.Payload)
+		case <-ctx.Done():
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMissionRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		delay    time.Duration
+	}{
+		{attempts: 1, delay: 10 * time.Second},
+		{attempts: 2, delay: 20 * time.Second},
+		{attempts: 3, delay: 40 * time.Second},
+		{attempts: 7, delay: 10 * time.Minute},
+		{attempts: 100, delay: 10 * time.Minute},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, test.delay, missionRetryDelay(test.attempts))
+		})
+	}
+}
+
+func TestMissionFinishUpdate(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	failed := errors.New("crawl failed")
+	tests := []struct {
+		attempts  int
+		err       error
+		status    string
+		finished  bool
+		nextRunAt time.Time
+	}{
+		{attempts: 1, err: nil, status: table.MissionStatusSuccess, finished: true},
+		{attempts: 1, err: failed, status: table.MissionStatusPending, finished: false, nextRunAt: now.Add(10 * time.Second)},
+		{attempts: 2, err: failed, status: table.MissionStatusPending, finished: false, nextRunAt: now.Add(20 * time.Second)},
+		// 达到最大执行次数后不再重试
+		{attempts: defaultMissionMaxAttempts, err: failed, status: table.MissionStatusFailed, finished: true},
+		{attempts: defaultMissionMaxAttempts, err: nil, status: table.MissionStatusSuccess, finished: true},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			update, finished := missionFinishUpdate(tt.attempts, CrawlerResult{Nick: "OnTheRocks"}, tt.err, now)
+			assert.Equal(t, tt.finished, finished)
+			assert.Equal(t, tt.status, update["status"])
+			assert.Equal(t, "", update["lease_owner"])
+			if tt.finished {
+				assert.Equal(t, now, update["finished_time"])
+				assert.NotContains(t, update, "next_run_at")
+			} else {
+				assert.Equal(t, tt.nextRunAt, update["next_run_at"])
+				assert.NotContains(t, update, "finished_time")
+			}
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), update["last_error"])
+			}
+		})
+	}
+}
+
+// resetTestMissions 清空任务表，避免测试之间互相领取任务
+func resetTestMissions(t *testing.T) {
+	m := dal.Mission
+	_, err := m.Unscoped().Where(m.ID.Gt(0)).Delete()
+	assert.NoError(t, err)
+}
+
+func TestClaimMission(t *testing.T) {
+	setupTestData(t)
+	resetTestMissions(t)
+	t.Cleanup(func() { resetTestMissions(t) })
+	m := dal.Mission
+
+	assert.NoError(t, SubmitMissionWithDetail("claim-due", table.MissionTypeUserInfo, ScheduleForm{Nick: "a"}))
+	assert.NoError(t, SubmitMissionWithDetail("claim-later", table.MissionTypeUserInfo, ScheduleForm{Nick: "b"}))
+	_, err := m.Where(m.MissionId.Eq("claim-later")).Update(m.NextRunAt, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	claimed, err := ClaimMission("worker-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "claim-due", claimed.MissionId)
+	assert.Equal(t, table.MissionStatusRunning, claimed.Status)
+	assert.Equal(t, 1, claimed.Attempts)
+	assert.Equal(t, "worker-1", claimed.LeaseOwner)
+	assert.True(t, claimed.LeaseUntil.After(time.Now()))
+
+	// 租约有效期内的任务和未到执行时间的任务都不会被领取
+	_, err = ClaimMission("worker-2")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 租约过期后被其他worker重新领取
+	_, err = m.Where(m.MissionId.Eq("claim-due")).Update(m.LeaseUntil, time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+	reclaimed, err := ClaimMission("worker-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "claim-due", reclaimed.MissionId)
+	assert.Equal(t, 2, reclaimed.Attempts)
+	assert.Equal(t, "worker-2", reclaimed.LeaseOwner)
+
+	// 失去租约的worker不能再结束任务
+	mustFinishMission(claimed, "worker-1", nil, errors.New("lost"))
+	mission, err := FindMission("claim-due")
+	assert.NoError(t, err)
+	assert.Equal(t, table.MissionStatusRunning, mission.Status)
+	assert.Equal(t, "worker-2", mission.LeaseOwner)
+
+	// 失败后回到待执行状态，延迟重试
+	mustFinishMission(reclaimed, "worker-2", nil, errors.New("retry"))
+	mission, err = FindMission("claim-due")
+	assert.NoError(t, err)
+	assert.Equal(t, table.MissionStatusPending, mission.Status)
+	assert.Equal(t, "", mission.LeaseOwner)
+	assert.Equal(t, "retry", mission.LastError)
+	assert.True(t, mission.NextRunAt.After(time.Now()))
+	_, err = ClaimMission("worker-3")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestClaimMissionSkipLocked(t *testing.T) {
+	setupTestData(t)
+	resetTestMissions(t)
+	t.Cleanup(func() { resetTestMissions(t) })
+
+	const count = 8
+	for i := 0; i < count; i++ {
+		assert.NoError(t, SubmitMissionWithDetail("skip-locked-"+strconv.Itoa(i), table.MissionTypeUserInfo, ScheduleForm{}))
+	}
+	var mu sync.Mutex
+	claimed := make(map[string]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(owner string) {
+			defer wg.Done()
+			for {
+				mission, err := ClaimMission(owner)
+				if err != nil {
+					assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+					return
+				}
+				mu.Lock()
+				claimed[mission.MissionId]++
+				mu.Unlock()
+			}
+		}("worker-" + strconv.Itoa(i))
+	}
+	wg.Wait()
+	// 并发领取时每个任务只会被领取一次
+	assert.Len(t, claimed, count)
+	for missionId, times := range claimed {
+		assert.Equal(t, 1, times, missionId)
+	}
+}
+
+func TestRunMissionMaxAttempts(t *testing.T) {
+	setupTestData(t)
+	setupTestCache(t)
+	resetTestMissions(t)
+	t.Cleanup(func() { resetTestMissions(t) })
+	const missionType = "test_always_fail"
+	calls := 0
+	missionHandlers[missionType] = func(mission *table.Mission) (any, error) {
+		calls++
+		return nil, errors.New("always fail")
+	}
+	t.Cleanup(func() { delete(missionHandlers, missionType) })
+	missionMaxAttempts = 2
+	t.Cleanup(func() { missionMaxAttempts = defaultMissionMaxAttempts })
+
+	m := dal.Mission
+	assert.NoError(t, SubmitMissionWithDetail("max-attempts", missionType, nil))
+	for i := 0; i < missionMaxAttempts; i++ {
+		mission, err := ClaimMission("worker-1")
+		assert.NoError(t, err)
+		runMission(mission, "worker-1")
+		// 跳过重试的延迟
+		_, err = m.Where(m.MissionId.Eq("max-attempts")).Update(m.NextRunAt, time.Now())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, missionMaxAttempts, calls)
+	mission, err := FindMission("max-attempts")
+	assert.NoError(t, err)
+	assert.Equal(t, table.MissionStatusFailed, mission.Status)
+	assert.Equal(t, missionMaxAttempts, mission.Attempts)
+	assert.Equal(t, "always fail", mission.LastError)
+	assert.True(t, isMissionFinished(mission))
+	_, err = ClaimMission("worker-1")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.True(t, WaitForMissionsFinished([]string{"max-attempts"}))
+}
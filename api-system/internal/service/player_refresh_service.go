@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultPlayerRefreshInterval  = time.Hour * 6
+	defaultPlayerRefreshBatchSize = 5
+)
+
+// playerRefreshRunning 等待爬取的时间可能超过定时任务的间隔，上一次未结束时跳过本次
+var playerRefreshRunning atomic.Bool
+
+// playerRefreshItem 到期的订阅或关注，二者只有一个不为空
+type playerRefreshItem struct {
+	nick      string
+	updatedAt time.Time
+	sub       *table.PlayerSubscription
+	watch     *table.BanWatch
+}
+
+// RefreshWatchedPlayers 刷新最久未处理的一批订阅和关注的玩家，然后通知订阅玩家的资料变化和关注玩家的封禁状态变化。
+// 订阅和关注共用同一批爬取，每次最多爬取batchSize个玩家，间隔内已经刷新过的玩家直接使用库内的数据
+func RefreshWatchedPlayers(interval time.Duration, batchSize int) error {
+	if interval <= 0 {
+		interval = defaultPlayerRefreshInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultPlayerRefreshBatchSize
+	}
+	if !playerRefreshRunning.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer playerRefreshRunning.Store(false)
+	now := time.Now()
+	deadline := now.Add(-interval)
+	items, err := findDueRefreshItems(deadline, batchSize)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	batch := pickPlayerRefreshBatch(items, batchSize)
+	// 刷新前记录关注玩家最后已知的数据
+	stats := make(map[string]table.GameUserSnapshot)
+	for _, nick := range batch.watchNicks {
+		stats[nick] = findLastKnownStat(nick)
+	}
+	refreshPlayers(batch.nicks, deadline)
+
+	notifySubscriptions(batch.subs, batch.subNicks, now)
+	if len(batch.watchNicks) > 0 {
+		return checkBanWatches(batch.watchNicks, deadline, stats, now)
+	}
+	return nil
+}
+
+// playerRefreshBatch 一次定时任务要处理的订阅和关注
+type playerRefreshBatch struct {
+	// 需要爬取的玩家
+	nicks []string
+	subs  []*table.PlayerSubscription
+	// 订阅对应的玩家昵称，没有绑定昵称的订阅不在其中
+	subNicks map[uint]string
+	// 需要检查封禁状态的玩家
+	watchNicks []string
+}
+
+// pickPlayerRefreshBatch 按顺序选出本次处理的订阅和关注。多个群订阅或关注同一个玩家时只爬取一次，
+// 超出batchSize的玩家留到下一次
+func pickPlayerRefreshBatch(items []playerRefreshItem, batchSize int) playerRefreshBatch {
+	batch := playerRefreshBatch{subNicks: make(map[uint]string)}
+	for _, item := range items {
+		if item.nick != "" && !slices.Contains(batch.nicks, item.nick) {
+			if len(batch.nicks) >= batchSize {
+				continue
+			}
+			batch.nicks = append(batch.nicks, item.nick)
+		}
+		if item.sub != nil {
+			batch.subs = append(batch.subs, item.sub)
+			if item.nick != "" {
+				batch.subNicks[item.sub.ID] = item.nick
+			}
+		} else if !slices.Contains(batch.watchNicks, item.nick) {
+			batch.watchNicks = append(batch.watchNicks, item.nick)
+		}
+	}
+	return batch
+}
+
+// findDueRefreshItems 到期的订阅和关注，按上次处理的时间排序。
+// 订阅的玩家以用户当前绑定的昵称为准，没有绑定时昵称为空
+func findDueRefreshItems(deadline time.Time, limit int) ([]playerRefreshItem, error) {
+	ps := dal.PlayerSubscription
+	subs, err := ps.Where(ps.CheckedAt.Lt(deadline)).Order(ps.UpdatedAt).Limit(limit).Find()
+	if err != nil {
+		return nil, err
+	}
+	// 资料不存在而跳过的玩家排到队尾，不会一直占用每次的名额
+	bw := dal.BanWatch
+	watches, err := bw.Where(bw.CheckedAt.Lt(deadline)).Order(bw.UpdatedAt).Limit(limit).Find()
+	if err != nil {
+		return nil, err
+	}
+	var items []playerRefreshItem
+	for _, sub := range subs {
+		item := playerRefreshItem{updatedAt: sub.UpdatedAt, sub: sub}
+		if config, err := FindUserConfig(sub.UserId); err == nil && config.BindingGameNick != nil {
+			item.nick = *config.BindingGameNick
+		}
+		items = append(items, item)
+	}
+	for _, watch := range watches {
+		items = append(items, playerRefreshItem{nick: watch.Nick, updatedAt: watch.UpdatedAt, watch: watch})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].updatedAt.Before(items[j].updatedAt)
+	})
+	return items, nil
+}
+
+// refreshPlayers 爬取玩家资料并等待结束。资料在deadline之后更新过的玩家不再爬取，
+// 库内没有资料的玩家在上次未找到后的一段时间内也不再爬取
+func refreshPlayers(nicks []string, deadline time.Time) {
+	var missionIds []string
+	for _, nick := range nicks {
+		if user, err := FindGameProfile(nick); err == nil {
+			if user.UpdatedAt.After(deadline) {
+				continue
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logging.L().Warn("find game profile failed", logging.Error(err))
+			continue
+		} else if !CanBeRefresh(nick) {
+			continue
+		}
+		missionId, err := RefreshWTUserInfo(nick, bot.Reply{})
+		if err != nil {
+			logging.L().Warn("refresh watched player failed",
+				logging.Error(err),
+				logging.Any("nick", nick))
+			continue
+		}
+		missionIds = append(missionIds, *missionId)
+	}
+	if len(missionIds) > 0 && !WaitForMissionsFinished(missionIds) {
+		logging.L().Warn("wait for watched player missions timeout")
+	}
+}
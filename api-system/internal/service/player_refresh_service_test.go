@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPickPlayerRefreshBatch(t *testing.T) {
+	sub := func(id uint) *table.PlayerSubscription {
+		s := &table.PlayerSubscription{}
+		s.ID = id
+		return s
+	}
+	items := []playerRefreshItem{
+		{nick: "OnTheRocks", sub: sub(1)},
+		{nick: "OnTheRocks", watch: &table.BanWatch{Nick: "OnTheRocks"}},
+		// 没有绑定昵称的订阅不需要爬取，但同样更新检查时间
+		{nick: "", sub: sub(2)},
+		{nick: "Cheater42", watch: &table.BanWatch{Nick: "Cheater42"}},
+		{nick: "Cheater42", watch: &table.BanWatch{GroupId: 2, Nick: "Cheater42"}},
+		// 超出batchSize的玩家留到下一次
+		{nick: "Pebble", sub: sub(3)},
+		{nick: "Pebble", watch: &table.BanWatch{Nick: "Pebble"}},
+		{nick: "OnTheRocks", sub: sub(4)},
+	}
+	batch := pickPlayerRefreshBatch(items, 2)
+	assert.Equal(t, []string{"OnTheRocks", "Cheater42"}, batch.nicks)
+	assert.Equal(t, []*table.PlayerSubscription{sub(1), sub(2), sub(4)}, batch.subs)
+	assert.Equal(t, map[uint]string{1: "OnTheRocks", 4: "OnTheRocks"}, batch.subNicks)
+	assert.Equal(t, []string{"OnTheRocks", "Cheater42"}, batch.watchNicks)
+
+	assert.Empty(t, pickPlayerRefreshBatch(nil, 2).nicks)
+}
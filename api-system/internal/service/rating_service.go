@@ -0,0 +1,92 @@
+package service
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"gorm.io/gen"
+	"sync"
+)
+
+const ratingBatchSize = 500
+
+var (
+	ratingBaseline     *table.GameUserRatingBaseline
+	ratingBaselineLock sync.RWMutex
+)
+
+// RefreshRatingBaseline 根据库内所有玩家的数据重新统计效率值的群体基准
+func RefreshRatingBaseline() (*table.GameUserRatingBaseline, error) {
+	var baseline table.GameUserRatingBaseline
+	var batch []*table.GameUser
+	if err := dal.GameUser.FindInBatches(&batch, ratingBatchSize, func(tx gen.Dao, _ int) error {
+		for _, user := range batch {
+			baseline.Add(*user)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	ratingBaselineLock.Lock()
+	ratingBaseline = &baseline
+	ratingBaselineLock.Unlock()
+	return &baseline, nil
+}
+
+// getRatingBaseline 获取当前的群体基准，尚未统计过时会先统计一次
+func getRatingBaseline() (*table.GameUserRatingBaseline, error) {
+	ratingBaselineLock.RLock()
+	baseline := ratingBaseline
+	ratingBaselineLock.RUnlock()
+	if baseline != nil {
+		return baseline, nil
+	}
+	return RefreshRatingBaseline()
+}
+
+func updateGameUserRating(user *table.GameUser) error {
+	gu := dal.GameUser
+	// 只更新效率值，不改变数据的最后刷新时间
+	_, err := gu.Where(gu.ID.Eq(user.ID)).UpdateColumnSimple(
+		gu.AsABRate.Value(user.AsABRate),
+		gu.AsRBRate.Value(user.AsRBRate),
+		gu.AsSBRate.Value(user.AsSBRate),
+	)
+	return err
+}
+
+// MustUpdateGameUserRating 使用当前的群体基准计算玩家的安东星效率值，在每次成功爬取后调用
+func MustUpdateGameUserRating(nick string) {
+	baseline, err := getRatingBaseline()
+	if err != nil {
+		logging.L().Warn("get rating baseline failed", logging.Error(err))
+		return
+	}
+	user, err := FindGameProfile(nick)
+	if err != nil {
+		logging.L().Warn("find game profile failed", logging.Error(err))
+		return
+	}
+	baseline.Apply(user)
+	if err := updateGameUserRating(user); err != nil {
+		logging.L().Error("dal error", logging.Error(err))
+	}
+}
+
+// RefreshAllGameUserRating 重新统计群体基准，并重新计算库内所有玩家的效率值
+func RefreshAllGameUserRating() error {
+	baseline, err := RefreshRatingBaseline()
+	if err != nil {
+		return err
+	}
+	var batch []*table.GameUser
+	return dal.GameUser.FindInBatches(&batch, ratingBatchSize, func(tx gen.Dao, _ int) error {
+		for _, user := range batch {
+			baseline.Apply(user)
+			if err := updateGameUserRating(user); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
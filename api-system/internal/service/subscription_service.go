@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/data/dal"
+	"github.com/axiangcoding/antonstar-bot/internal/data/display"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"gorm.io/gorm"
+	"time"
+)
+
+// subscriptionTarget 发送通知的群
+type subscriptionTarget struct {
+	Platform        string
+	SelfId          int64
+	GroupId         int64
+	MessageTemplate int
+}
+
+func FindPlayerSubscription(userId int64, groupId int64) (*table.PlayerSubscription, error) {
+	ps := dal.PlayerSubscription
+	return ps.Where(ps.UserId.Eq(userId), ps.GroupId.Eq(groupId)).Take()
+}
+
+// SubscribePlayer 在群内订阅用户绑定的玩家，已订阅时更新发送通知使用的账号和消息模板
+func SubscribePlayer(sendForm bot.Reply, nick string) error {
+	sub, err := FindPlayerSubscription(sendForm.UserId, sendForm.GroupId)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		sub = &table.PlayerSubscription{
+			UserId:  sendForm.UserId,
+			GroupId: sendForm.GroupId,
+		}
+	}
+	sub.Platform = sendForm.Platform
+	sub.SelfId = sendForm.SelfId
+	sub.MessageTemplate = sendForm.MessageTemplate
+	// 以当前已有的资料作为基准，之后的变化才会通知
+	if user, err := FindGameProfile(nick); err == nil {
+		sub.Track(*user, time.Now())
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return dal.PlayerSubscription.Save(sub)
+}
+
+// UnsubscribePlayer 取消订阅，返回是否存在订阅
+func UnsubscribePlayer(userId int64, groupId int64) (bool, error) {
+	ps := dal.PlayerSubscription
+	info, err := ps.Unscoped().Where(ps.UserId.Eq(userId), ps.GroupId.Eq(groupId)).Delete()
+	if err != nil {
+		return false, err
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// MustRemovePlayerSubscriptions 机器人退群后删除该群的所有订阅
+func MustRemovePlayerSubscriptions(groupId int64) {
+	ps := dal.PlayerSubscription
+	if _, err := ps.Unscoped().Where(ps.GroupId.Eq(groupId)).Delete(); err != nil {
+		logging.L().Error("dal failed", logging.Error(err))
+	}
+}
+
+// notifySubscriptions 对比刷新后的玩家资料，将订阅玩家的变化按群汇总后发送，nicks为订阅对应的玩家昵称
+func notifySubscriptions(subs []*table.PlayerSubscription, nicks map[uint]string, now time.Time) {
+	ps := dal.PlayerSubscription
+	digests := make(map[subscriptionTarget][]display.PlayerChange)
+	for _, sub := range subs {
+		sub.CheckedAt = now
+		if nick, ok := nicks[sub.ID]; ok {
+			if user, err := FindGameProfile(nick); err == nil {
+				if changes := sub.Diff(*user); len(changes) > 0 {
+					target := subscriptionTarget{
+						Platform:        sub.Platform,
+						SelfId:          sub.SelfId,
+						GroupId:         sub.GroupId,
+						MessageTemplate: sub.MessageTemplate,
+					}
+					digests[target] = append(digests[target], changes...)
+				}
+				sub.Track(*user, now)
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				logging.L().Warn("find game profile failed", logging.Error(err))
+			}
+		}
+		if err := ps.Save(sub); err != nil {
+			logging.L().Error("dal failed", logging.Error(err))
+		}
+	}
+	if IsStopAllResponse() {
+		return
+	}
+	for target, changes := range digests {
+		bot.MustSend(bot.Reply{
+			Platform:        target.Platform,
+			SelfId:          target.SelfId,
+			MessageType:     bot.MessageTypeGroup,
+			GroupId:         target.GroupId,
+			Message:         display.PlayerChangeDigest{Changes: changes}.ToFriendlyString(),
+			MessageTemplate: target.MessageTemplate,
+		})
+	}
+}
@@ -43,6 +43,9 @@ func UpdateUserConfigBindingGameNick(userId int64, gameNick *string) error {
 		return errors.New("qq_user_config not exist")
 	} else {
 		config.BindingGameNick = gameNick
+		// 更换或解除绑定后需要重新验证
+		falseVal := false
+		config.BindingVerified = &falseVal
 	}
 	if err := SaveUserConfig(*config); err != nil {
 		return err
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"sync"
+)
+
+const (
+	PlatformCqHttp = "cqhttp"
+	PlatformKook   = "kook"
+)
+
+const (
+	MessageTypeGroup   = "group"
+	MessageTypePrivate = "private"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// Message 平台无关的入站消息，由各平台适配器从原始事件转换而来
+type Message struct {
+	Platform    string `json:"platform"`
+	SelfId      int64  `json:"self_id"`
+	MessageType string `json:"message_type"`
+	GroupId     int64  `json:"group_id"`
+	UserId      int64  `json:"user_id"`
+	// 发送者在群内的角色，平台不支持时为空
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Reply 平台无关的出站回复，会随任务详情一起保存，因此需要可以被序列化
+type Reply struct {
+	Platform        string `json:"platform,omitempty"`
+	SelfId          int64  `json:"self_id,omitempty"`
+	MessageType     string `json:"message_type,omitempty"`
+	MessagePrefix   string `json:"message_prefix,omitempty"`
+	GroupId         int64  `json:"group_id,omitempty"`
+	UserId          int64  `json:"user_id,omitempty"`
+	Message         string `json:"message,omitempty"`
+	MessageTemplate int    `json:"message_template,omitempty"`
+}
+
+// NewReply 生成一个回复到消息来源会话的回复
+func (m Message) NewReply() Reply {
+	return Reply{
+		Platform:    m.Platform,
+		SelfId:      m.SelfId,
+		MessageType: m.MessageType,
+		GroupId:     m.GroupId,
+		UserId:      m.UserId,
+	}
+}
+
+func (r Reply) IsPrivate() bool {
+	return r.MessageType == MessageTypePrivate
+}
+
+// Adapter 聊天平台适配器，负责把平台无关的回复发送到具体平台
+type Adapter interface {
+	Platform() string
+	// Mention 生成@指定用户的消息前缀
+	Mention(userId int64) string
+	Send(reply Reply) error
+}
+
+var (
+	adapters   = make(map[string]Adapter)
+	adaptersMu sync.RWMutex
+)
+
+func RegisterAdapter(adapter Adapter) {
+	adaptersMu.Lock()
+	defer adaptersMu.Unlock()
+	adapters[adapter.Platform()] = adapter
+}
+
+func GetAdapter(platform string) (Adapter, bool) {
+	adaptersMu.RLock()
+	defer adaptersMu.RUnlock()
+	// 历史数据中没有平台字段，默认为cqhttp
+	if platform == "" {
+		platform = PlatformCqHttp
+	}
+	adapter, ok := adapters[platform]
+	return adapter, ok
+}
+
+func Mention(platform string, userId int64) string {
+	adapter, ok := GetAdapter(platform)
+	if !ok {
+		return ""
+	}
+	return adapter.Mention(userId)
+}
+
+func Send(reply Reply) error {
+	adapter, ok := GetAdapter(reply.Platform)
+	if !ok {
+		return fmt.Errorf("no adapter for platform %s", reply.Platform)
+	}
+	return adapter.Send(reply)
+}
+
+func MustSend(reply Reply) {
+	if err := Send(reply); err != nil {
+		logging.L().Error("send reply failed",
+			logging.Any("platform", reply.Platform),
+			logging.Error(err))
+	}
+}
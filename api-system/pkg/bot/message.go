@@ -8,6 +8,9 @@ import (
 	"strings"
 )
 
+// MessageTemplates 可选的语气类型，0为默认，1为二次元
+var MessageTemplates = []int{0, 1}
+
 func SelectStaticMessage(id int) StaticMessage {
 	var filename string
 	if id == 0 {
@@ -26,6 +29,11 @@ func SelectStaticMessage(id int) StaticMessage {
 	return msg
 }
 
+// ContainsTrigger 消息是否以机器人的触发词开头
+func ContainsTrigger(msg string) bool {
+	return MessageGetCmdPrimaryMsgPattern.MatchString(msg)
+}
+
 func ParseMessageCommand(msg string) *Action {
 	sub := MessageGetCmdPrimaryMsgPattern.FindStringSubmatch(msg)
 	if len(sub) <= 1 {
@@ -69,8 +77,40 @@ func ParseMessageCommand(msg string) *Action {
 		key = ActionManager
 	case "绑定":
 		key = ActionBinding
+	case "验证":
+		key = ActionBindingVerify
 	case "解绑":
 		key = ActionUnbinding
+	case "趋势":
+		key = ActionTrend
+	case "对比":
+		key = ActionCompare
+	case "对战":
+		key = ActionCardFight
+	case "卡牌":
+		key = ActionCardList
+	case "对战排行":
+		key = ActionCardRank
+	case "回放":
+		key = ActionCardReplay
+	case "团战":
+		key = ActionCardTeam
+	case "锦标赛":
+		key = ActionTournament
+	case "联队":
+		key = ActionClan
+	case "联队排行":
+		key = ActionClanRank
+	case "排行":
+		key = ActionLeaderboard
+	case "订阅":
+		key = ActionSubscribe
+	case "取消订阅":
+		key = ActionUnsubscribe
+	case "关注":
+		key = ActionBanWatch
+	case "取消关注":
+		key = ActionBanUnwatch
 	default:
 		key = ActionUnknown
 	}
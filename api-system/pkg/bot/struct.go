@@ -7,23 +7,74 @@ var (
 )
 
 var (
-	ActionUnknown      = "unknown"
-	ActionQuery        = "query"
-	ActionFullQuery    = "fullQuery"
-	ActionRefresh      = "refresh"
-	ActionReport       = "report"
-	ActionDrawCard     = "drawCard"
-	ActionLuck         = "luck"
-	ActionVersion      = "version"
-	ActionGetHelp      = "getHelp"
-	ActionGroupStatus  = "groupStatus"
-	ActionData         = "data"
-	ActionManager      = "manager"
-	ActionGroupManager = "groupManager"
-	ActionBinding      = "binding"
-	ActionUnbinding    = "unbinding"
+	ActionUnknown       = "unknown"
+	ActionQuery         = "query"
+	ActionFullQuery     = "fullQuery"
+	ActionRefresh       = "refresh"
+	ActionReport        = "report"
+	ActionDrawCard      = "drawCard"
+	ActionLuck          = "luck"
+	ActionVersion       = "version"
+	ActionGetHelp       = "getHelp"
+	ActionGroupStatus   = "groupStatus"
+	ActionData          = "data"
+	ActionManager       = "manager"
+	ActionGroupManager  = "groupManager"
+	ActionBinding       = "binding"
+	ActionUnbinding     = "unbinding"
+	ActionBindingVerify = "bindingVerify"
+	ActionTrend         = "trend"
+	ActionCompare       = "compare"
+	ActionCardFight     = "cardFight"
+	ActionCardList      = "cardList"
+	ActionCardRank      = "cardRank"
+	ActionCardReplay    = "cardReplay"
+	ActionCardTeam      = "cardTeam"
+	ActionTournament    = "tournament"
+	ActionClan          = "clan"
+	ActionClanRank      = "clanRank"
+	ActionLeaderboard   = "leaderboard"
+	ActionSubscribe     = "subscribe"
+	ActionUnsubscribe   = "unsubscribe"
+	ActionBanWatch      = "banWatch"
+	ActionBanUnwatch    = "banUnwatch"
 )
 
+// Actions 全部的指令，新增指令时需要同时在功能开关中登记
+var Actions = []string{
+	ActionUnknown,
+	ActionQuery,
+	ActionFullQuery,
+	ActionRefresh,
+	ActionReport,
+	ActionDrawCard,
+	ActionLuck,
+	ActionVersion,
+	ActionGetHelp,
+	ActionGroupStatus,
+	ActionData,
+	ActionManager,
+	ActionGroupManager,
+	ActionBinding,
+	ActionUnbinding,
+	ActionBindingVerify,
+	ActionTrend,
+	ActionCompare,
+	ActionCardFight,
+	ActionCardList,
+	ActionCardRank,
+	ActionCardReplay,
+	ActionCardTeam,
+	ActionTournament,
+	ActionClan,
+	ActionClanRank,
+	ActionLeaderboard,
+	ActionSubscribe,
+	ActionUnsubscribe,
+	ActionBanWatch,
+	ActionBanUnwatch,
+}
+
 type Action struct {
 	Key   string `json:"key,omitempty"`
 	Value string `json:"value,omitempty"`
@@ -33,39 +84,102 @@ type StaticMessage struct {
 	Id         int    `json:"id"`
 	Mode       string `json:"mode"`
 	CommonResp struct {
-		Common                  string `json:"common"`
-		Report                  string `json:"report"`
-		CanNotRefresh           string `json:"can_not_refresh"`
-		TooShortToRefresh       string `json:"too_short_to_refresh"`
-		QueryIsRunning          string `json:"query_is_running"`
-		NotValidNickname        string `json:"not_valid_nickname"`
-		GetHelp                 string `json:"get_help"`
-		DrawCard                string `json:"draw_card"`
-		Luck                    string `json:"luck"`
-		GroupGetBanned          string `json:"group_get_banned"`
-		UserGetBanned           string `json:"user_get_banned"`
-		TodayUserQueryLimit     string `json:"today_user_query_limit"`
-		TodayGroupQueryLimit    string `json:"today_group_query_limit"`
-		TodayUserUsageLimit     string `json:"today_user_usage_limit"`
-		TodayGroupUsageLimit    string `json:"today_group_usage_limit"`
-		Version                 string `json:"version"`
-		LiveBroadcast           string `json:"live_broadcast"`
-		StopGlobalQuery         string `json:"stop_global_query"`
-		DataOptions             string `json:"data_options"`
-		MissileData             string `json:"missile_data"`
-		BindingFirst            string `json:"binding_first"`
-		BindingNickNotExist     string `json:"binding_nick_not_exist"`
-		BindingExist            string `json:"binding_exist"`
-		BindingSuccess          string `json:"binding_success"`
-		BindingError            string `json:"binding_error"`
-		UnbindingError          string `json:"unbinding_error"`
-		UnbindingSuccess        string `json:"unbinding_success"`
-		ConfOptions             string `json:"conf_options"`
-		ConfNotPermit           string `json:"conf_not_permit"`
-		ConfStopGlobalResponse  string `json:"conf_stop_global_response"`
-		ConfStartGlobalResponse string `json:"conf_start_global_response"`
-		ConfStopGlobalQuery     string `json:"conf_stop_global_query"`
-		ConfStartGlobalQuery    string `json:"conf_start_global_query"`
+		Common                       string `json:"common"`
+		Report                       string `json:"report"`
+		CanNotRefresh                string `json:"can_not_refresh"`
+		TooShortToRefresh            string `json:"too_short_to_refresh"`
+		QueryIsRunning               string `json:"query_is_running"`
+		NotValidNickname             string `json:"not_valid_nickname"`
+		GetHelp                      string `json:"get_help"`
+		DrawCard                     string `json:"draw_card"`
+		Luck                         string `json:"luck"`
+		GroupGetBanned               string `json:"group_get_banned"`
+		UserGetBanned                string `json:"user_get_banned"`
+		TodayUserQueryLimit          string `json:"today_user_query_limit"`
+		TodayGroupQueryLimit         string `json:"today_group_query_limit"`
+		TodayUserUsageLimit          string `json:"today_user_usage_limit"`
+		TodayGroupUsageLimit         string `json:"today_group_usage_limit"`
+		Version                      string `json:"version"`
+		LiveBroadcast                string `json:"live_broadcast"`
+		StopGlobalQuery              string `json:"stop_global_query"`
+		DataOptions                  string `json:"data_options"`
+		MissileData                  string `json:"missile_data"`
+		BindingFirst                 string `json:"binding_first"`
+		BindingNickNotExist          string `json:"binding_nick_not_exist"`
+		BindingExist                 string `json:"binding_exist"`
+		BindingSuccess               string `json:"binding_success"`
+		BindingError                 string `json:"binding_error"`
+		UnbindingError               string `json:"unbinding_error"`
+		UnbindingSuccess             string `json:"unbinding_success"`
+		BindingVerifyCode            string `json:"binding_verify_code"`
+		BindingVerifyNotStarted      string `json:"binding_verify_not_started"`
+		BindingVerifyMismatch        string `json:"binding_verify_mismatch"`
+		BindingVerifyFailed          string `json:"binding_verify_failed"`
+		SubscribeSuccess             string `json:"subscribe_success"`
+		SubscribeFailed              string `json:"subscribe_failed"`
+		UnsubscribeSuccess           string `json:"unsubscribe_success"`
+		UnsubscribeNotExist          string `json:"unsubscribe_not_exist"`
+		BanWatchSuccess              string `json:"ban_watch_success"`
+		BanWatchLimit                string `json:"ban_watch_limit"`
+		BanWatchFailed               string `json:"ban_watch_failed"`
+		BanWatchListEmpty            string `json:"ban_watch_list_empty"`
+		BanUnwatchSuccess            string `json:"ban_unwatch_success"`
+		BanUnwatchNotExist           string `json:"ban_unwatch_not_exist"`
+		ConfOptions                  string `json:"conf_options"`
+		ConfNotPermit                string `json:"conf_not_permit"`
+		ConfStopGlobalResponse       string `json:"conf_stop_global_response"`
+		ConfStartGlobalResponse      string `json:"conf_start_global_response"`
+		ConfStopGlobalQuery          string `json:"conf_stop_global_query"`
+		ConfStartGlobalQuery         string `json:"conf_start_global_query"`
+		OnlyInGroup                  string `json:"only_in_group"`
+		TrendNotEnough               string `json:"trend_not_enough"`
+		CompareUsage                 string `json:"compare_usage"`
+		CompareNotFound              string `json:"compare_not_found"`
+		CompareFailed                string `json:"compare_failed"`
+		ConfTargetInvalid            string `json:"conf_target_invalid"`
+		ConfSuccess                  string `json:"conf_success"`
+		ConfFailed                   string `json:"conf_failed"`
+		GroupConfOptions             string `json:"group_conf_options"`
+		GroupConfNotPermit           string `json:"group_conf_not_permit"`
+		GroupConfOwnerOnly           string `json:"group_conf_owner_only"`
+		GroupConfSuccess             string `json:"group_conf_success"`
+		GroupConfFailed              string `json:"group_conf_failed"`
+		GroupConfInvalidValue        string `json:"group_conf_invalid_value"`
+		GroupConfBindBiliFirst       string `json:"group_conf_bind_bili_first"`
+		FeatureDisabled              string `json:"feature_disabled"`
+		GroupShutdown                string `json:"group_shutdown"`
+		DrawCardLimit                string `json:"draw_card_limit"`
+		CardFightUsage               string `json:"card_fight_usage"`
+		CardFightSelf                string `json:"card_fight_self"`
+		CardNoCard                   string `json:"card_no_card"`
+		CardFightEnemyNoCard         string `json:"card_fight_enemy_no_card"`
+		CardNotFound                 string `json:"card_not_found"`
+		CardFightWin                 string `json:"card_fight_win"`
+		CardFightDraw                string `json:"card_fight_draw"`
+		CardFightRankEmpty           string `json:"card_fight_rank_empty"`
+		CardFailed                   string `json:"card_failed"`
+		CardFightReplayHint          string `json:"card_fight_replay_hint"`
+		CardFightReplayUsage         string `json:"card_fight_replay_usage"`
+		CardFightReplayNotFound      string `json:"card_fight_replay_not_found"`
+		CardTeamFightUsage           string `json:"card_team_fight_usage"`
+		CardTournamentUsage          string `json:"card_tournament_usage"`
+		CardTournamentCreated        string `json:"card_tournament_created"`
+		CardTournamentExist          string `json:"card_tournament_exist"`
+		CardTournamentNotFound       string `json:"card_tournament_not_found"`
+		CardTournamentNotRegistering string `json:"card_tournament_not_registering"`
+		CardTournamentJoined         string `json:"card_tournament_joined"`
+		CardTournamentAlreadyJoined  string `json:"card_tournament_already_joined"`
+		CardTournamentNotEnough      string `json:"card_tournament_not_enough"`
+		CardTournamentStarted        string `json:"card_tournament_started"`
+		CardTournamentCanceled       string `json:"card_tournament_canceled"`
+		CardTournamentNotPermit      string `json:"card_tournament_not_permit"`
+		ClanUsage                    string `json:"clan_usage"`
+		ClanNotFound                 string `json:"clan_not_found"`
+		ClanFailed                   string `json:"clan_failed"`
+		ClanRankEmpty                string `json:"clan_rank_empty"`
+		LeaderboardUsage             string `json:"leaderboard_usage"`
+		LeaderboardEmpty             string `json:"leaderboard_empty"`
+		LeaderboardFailed            string `json:"leaderboard_failed"`
 	} `json:"common_resp"`
 	LuckResp struct {
 		Is0          string `json:"is_0"`
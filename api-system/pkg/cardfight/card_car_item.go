@@ -3,8 +3,6 @@ package cardfight
 import (
 	"fmt"
 	"math"
-	"math/rand"
-
 	"strings"
 )
 
@@ -43,6 +41,7 @@ func InitCarItem(name string,
 			name:              name,
 			user:              user,
 			cardType:          CardItemCar,
+			class:             CardClassTank,
 			memberProficiency: memberProficiency,
 		},
 		velocity:   velocity,
@@ -59,19 +58,46 @@ func InitCarItem(name string,
 	}
 }
 
-func (i *CardCarItem) TakeStepWithCar(enemy *CardCarItem) []string {
-	res := make([]string, 0)
+// InitSPAAItem 防空车，可以攻击空中目标
+func InitSPAAItem(name string,
+	user string, memberProficiency float64,
+	velocity float64, firepower float64, protection float64) *CardCarItem {
+	item := InitCarItem(name, user, memberProficiency, velocity, firepower, protection)
+	item.class = CardClassSPAA
+	return item
+}
+
+func (i *CardCarItem) Velocity() float64 {
+	return i.velocity
+}
+
+// TakeStep 根据敌方卡牌的类型进行行动，只有防空车可以攻击空中目标
+func (i *CardCarItem) TakeStep(enemy CardAction) []FightEvent {
+	switch e := enemy.(type) {
+	case *CardCarItem:
+		return i.TakeStepWithCar(e)
+	case *CardJetItem:
+		return i.TakeStepWithJet(e)
+	}
+	return nil
+}
+
+func (i *CardCarItem) TakeStepWithJet(enemy *CardJetItem) []FightEvent {
+	if i.class != CardClassSPAA {
+		return []FightEvent{i.repair("无法攻击空中目标，"), newStatusEvent(i)}
+	} else if i.module.Barrel <= 33 {
+		return []FightEvent{i.repair("由于炮管故障无法攻击，"), newStatusEvent(i)}
+	}
+	return []FightEvent{i.antiAir(enemy), newStatusEvent(enemy)}
+}
+
+func (i *CardCarItem) TakeStepWithCar(enemy *CardCarItem) []FightEvent {
 	if i.module.Barrel <= 33 {
-		res = append(res, "由于炮管故障无法攻击，"+i.repair())
-		res = append(res, i.ModuleStatus())
+		return []FightEvent{i.repair("由于炮管故障无法攻击，"), newStatusEvent(i)}
 	} else if i.module.GunSteady <= 33 {
-		res = append(res, "由于垂稳故障无法攻击，"+i.repair())
-		res = append(res, i.ModuleStatus())
-	} else {
-		res = append(res, i.attack(enemy))
-		res = append(res, enemy.ModuleStatus())
+		return []FightEvent{i.repair("由于垂稳故障无法攻击，"), newStatusEvent(i)}
 	}
-	return res
+	return []FightEvent{i.attack(enemy), newStatusEvent(enemy)}
 }
 
 func (i *CardCarItem) IsDead() (bool, string) {
@@ -114,75 +140,86 @@ func (i *CardCarItem) ModuleStatus() string {
 	}
 }
 
-func (i *CardCarItem) attack(enemy *CardCarItem) string {
-	text := i.attackModules(40, i.firepower, enemy.protection, enemy.velocity, &enemy.module)
+func (i *CardCarItem) attack(enemy *CardCarItem) FightEvent {
+	damages := attackCarModules(i.dice, 40, i.firepower, enemy.protection, enemy.velocity, &enemy.module)
 	msgTemplate := "%s 攻击 %s，%s"
-	return fmt.Sprintf(msgTemplate, i.displayName(), enemy.displayName(), text)
+	return FightEvent{
+		Type:    FightEventAttack,
+		Actor:   i.displayName(),
+		Target:  enemy.displayName(),
+		Damages: damages,
+		Text:    fmt.Sprintf(msgTemplate, i.displayName(), enemy.displayName(), describeDamages(damages, "未能击穿他们的装甲")),
+	}
 }
 
-func (i *CardCarItem) repair() string {
-	text := i.repairModules(30, i.memberProficiency, &i.module)
-	msgTemplate := "%s 进行了维修，修复了 %s"
-	return fmt.Sprintf(msgTemplate, i.displayName(), text)
+// antiAir 防空车对空中目标有额外的伤害
+func (i *CardCarItem) antiAir(enemy *CardJetItem) FightEvent {
+	damages := attackJetModules(i.dice, 40*1.5, i.firepower, enemy.protection, enemy.velocity, &enemy.module)
+	msgTemplate := "%s 对空射击 %s，%s"
+	return FightEvent{
+		Type:    FightEventAttack,
+		Actor:   i.displayName(),
+		Target:  enemy.displayName(),
+		Damages: damages,
+		Text:    fmt.Sprintf(msgTemplate, i.displayName(), enemy.displayName(), describeDamages(damages, "未能命中")),
+	}
 }
 
-func (i *CardCarItem) attackModules(baseDamage float64, attack float64, defend float64, dodge float64, module *CardCarModule) string {
-	res := make([]string, 0)
+// repair 无法攻击时进行维修，reason为无法攻击的原因
+func (i *CardCarItem) repair(reason string) FightEvent {
+	repairs := i.repairModules(30, i.memberProficiency, &i.module)
+	msgTemplate := "%s%s 进行了维修，修复了 %s"
+	return FightEvent{
+		Type:    FightEventRepair,
+		Actor:   i.displayName(),
+		Repairs: repairs,
+		Text:    fmt.Sprintf(msgTemplate, reason, i.displayName(), strings.Join(repairs, "，")),
+	}
+}
+
+func attackCarModules(dice *Dice, baseDamage float64, attack float64, defend float64, dodge float64, module *CardCarModule) []ModuleDamage {
+	res := make([]ModuleDamage, 0)
 	baseProb := 0.4
 	prob := baseProb * (2 - dodge*0.1)
-	if CalProbabilities(prob * 0.2) {
-		var damage float64
-		var txt string
-		if CalProbabilities(0.5) {
-			damage = 100
-			txt = "弹药架（-100% 暴击！）"
-		} else {
-			damage = calDamage(baseDamage, attack, defend)
-			txt = fmt.Sprintf("弹药架（-%.2f%%）", damage)
+	if dice.CalProbabilities(prob * 0.2) {
+		if dice.CalProbabilities(0.5) {
+			module.AmmunitionRacks -= 100
+			return append(res, ModuleDamage{Module: "弹药架", Damage: 100, Critical: true})
 		}
+		damage := calDamage(dice, baseDamage, attack, defend)
 		module.AmmunitionRacks -= damage
-
-		if damage == 100 {
-			return txt
-		}
-		res = append(res, txt)
+		res = append(res, ModuleDamage{Module: "弹药架", Damage: damage})
 	}
 
-	if CalProbabilities(prob) {
-		damage := calDamage(baseDamage, attack, defend)
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
 		module.Crew -= damage
-		res = append(res, fmt.Sprintf("成员（-%.2f%%）", damage))
+		res = append(res, ModuleDamage{Module: "成员", Damage: damage})
 	}
-	if CalProbabilities(prob * 1.1) {
-		damage := calDamage(baseDamage, attack, defend)
+	if dice.CalProbabilities(prob * 1.1) {
+		damage := calDamage(dice, baseDamage, attack, defend)
 		module.Track -= damage
-		res = append(res, fmt.Sprintf("履带（-%.2f%%）", damage))
+		res = append(res, ModuleDamage{Module: "履带", Damage: damage})
 	}
-	if CalProbabilities(prob) {
-		damage := calDamage(baseDamage, attack, defend)
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
 		module.GunSteady -= damage
-		res = append(res, fmt.Sprintf("垂稳（-%.2f%%）", damage))
-
+		res = append(res, ModuleDamage{Module: "垂稳", Damage: damage})
 	}
-	if CalProbabilities(prob) {
-		damage := calDamage(baseDamage, attack, defend)
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
 		module.Barrel -= damage
-		res = append(res, fmt.Sprintf("炮管（-%.2f%%）", damage))
+		res = append(res, ModuleDamage{Module: "炮管", Damage: damage})
 	}
-	if CalProbabilities(prob) {
-		damage := calDamage(baseDamage, attack, defend)
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
 		module.Motor -= damage
-		res = append(res, fmt.Sprintf("引擎（-%.2f%%）", damage))
-	}
-
-	if len(res) > 0 {
-		return "对其造成了 " + strings.Join(res, "，")
-	} else {
-		return "未能击穿他们的装甲"
+		res = append(res, ModuleDamage{Module: "引擎", Damage: damage})
 	}
+	return res
 }
 
-func (i *CardCarItem) repairModules(baseRepair float64, Proficiency float64, module *CardCarModule) string {
+func (i *CardCarItem) repairModules(baseRepair float64, Proficiency float64, module *CardCarModule) []string {
 	res := make([]string, 0)
 	repair := baseRepair * (0.1 * Proficiency)
 
@@ -222,10 +259,10 @@ func (i *CardCarItem) repairModules(baseRepair float64, Proficiency float64, mod
 		repair -= f
 		res = append(res, "履带")
 	}
-	return strings.Join(res, "，")
+	return res
 }
 
-func calDamage(baseDamage float64, attack float64, defend float64) float64 {
+func calDamage(dice *Dice, baseDamage float64, attack float64, defend float64) float64 {
 	damage := 40.0
 	if baseDamage != 0.0 {
 		damage = baseDamage
@@ -238,6 +275,6 @@ func calDamage(baseDamage float64, attack float64, defend float64) float64 {
 	}
 	floatMin := 0.7
 	floatMax := 1.1
-	finalDamage := (floatMin + rand.Float64()*(floatMax-floatMin)) * damage
+	finalDamage := (floatMin + dice.Float64()*(floatMax-floatMin)) * damage
 	return finalDamage
 }
@@ -0,0 +1,145 @@
+package cardfight
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// 卡牌的稀有度
+const (
+	CardRarityCommon    = "common"
+	CardRarityRare      = "rare"
+	CardRarityEpic      = "epic"
+	CardRarityLegendary = "legendary"
+)
+
+var cardRarityNames = map[string]string{
+	CardRarityCommon:    "普通",
+	CardRarityRare:      "稀有",
+	CardRarityEpic:      "史诗",
+	CardRarityLegendary: "传说",
+}
+
+// RarityName 稀有度的中文名称，未知的稀有度视为普通
+func RarityName(rarity string) string {
+	if name, ok := cardRarityNames[rarity]; ok {
+		return name
+	}
+	return cardRarityNames[CardRarityCommon]
+}
+
+// Card 可以被抽到的载具卡牌
+type Card struct {
+	Name string `json:"name"`
+	// 国家
+	Nation string `json:"nation"`
+	// 权重
+	BattleRating float64 `json:"br"`
+	// 等级
+	Rank int `json:"rank"`
+	// 兵种
+	Class string `json:"class"`
+	// 机动
+	Velocity float64 `json:"velocity"`
+	// 火力
+	Firepower float64 `json:"firepower"`
+	// 防护
+	Protection float64 `json:"protection"`
+	// 稀有度
+	Rarity string `json:"rarity"`
+}
+
+// NewItem 按兵种生成由user使用的对战卡牌，未知的兵种视为坦克
+func (c Card) NewItem(user string, memberProficiency float64) CardAction {
+	switch c.Class {
+	case CardClassSPAA:
+		return InitSPAAItem(c.Name, user, memberProficiency, c.Velocity, c.Firepower, c.Protection)
+	case CardClassFighter, CardClassAttacker:
+		return InitJetItem(c.Name, c.Class, user, memberProficiency, c.Velocity, c.Firepower, c.Protection)
+	default:
+		return InitCarItem(c.Name, user, memberProficiency, c.Velocity, c.Firepower, c.Protection)
+	}
+}
+
+// Catalogue 卡牌图鉴，同时也是抽卡的卡池
+type Catalogue struct {
+	// RarityWeights 每种稀有度的卡牌被抽到的权重
+	RarityWeights map[string]int `json:"rarity_weights"`
+	Cards         []Card         `json:"cards"`
+	index         map[string]int
+	totalWeight   int
+}
+
+// ParseCatalogue 解析卡牌图鉴，卡牌名称不能重复，稀有度必须配置了权重
+func ParseCatalogue(bytes []byte) (*Catalogue, error) {
+	var c Catalogue
+	if err := json.Unmarshal(bytes, &c); err != nil {
+		return nil, err
+	}
+	if len(c.Cards) == 0 {
+		return nil, errors.New("empty card catalogue")
+	}
+	c.index = make(map[string]int, len(c.Cards))
+	for i, card := range c.Cards {
+		if _, ok := c.index[card.Name]; ok {
+			return nil, fmt.Errorf("duplicate card %s", card.Name)
+		}
+		weight, ok := c.RarityWeights[card.Rarity]
+		if !ok || weight <= 0 {
+			return nil, fmt.Errorf("card %s has invalid rarity %s", card.Name, card.Rarity)
+		}
+		c.index[card.Name] = i
+		c.totalWeight += weight
+	}
+	return &c, nil
+}
+
+// Find 按名称查询卡牌
+func (c *Catalogue) Find(name string) (Card, bool) {
+	i, ok := c.index[name]
+	if !ok {
+		return Card{}, false
+	}
+	return c.Cards[i], true
+}
+
+// Draw 按稀有度的权重随机抽取一张卡牌
+func (c *Catalogue) Draw(dice *Dice) Card {
+	n := dice.Intn(c.totalWeight)
+	for _, card := range c.Cards {
+		n -= c.RarityWeights[card.Rarity]
+		if n < 0 {
+			return card
+		}
+	}
+	return c.Cards[len(c.Cards)-1]
+}
+
+// Probability 抽到指定卡牌的概率
+func (c *Catalogue) Probability(card Card) float64 {
+	return float64(c.RarityWeights[card.Rarity]) / float64(c.totalWeight)
+}
+
+var defaultCatalogue atomic.Pointer[Catalogue]
+
+// LoadDefaultCatalogue 解析卡牌图鉴并作为默认图鉴，需要在使用 DefaultCatalogue 和 DrawCard 之前调用
+func LoadDefaultCatalogue(data []byte) error {
+	c, err := ParseCatalogue(data)
+	if err != nil {
+		return err
+	}
+	defaultCatalogue.Store(c)
+	return nil
+}
+
+// DefaultCatalogue 通过 LoadDefaultCatalogue 加载的卡牌图鉴，未加载时为nil
+func DefaultCatalogue() *Catalogue {
+	return defaultCatalogue.Load()
+}
+
+// DrawCard 从默认的卡牌图鉴中随机抽取一张卡牌
+func DrawCard() Card {
+	return DefaultCatalogue().Draw(NewDice(NewSeed()))
+}
@@ -0,0 +1,54 @@
+package cardfight
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestParseCatalogue(t *testing.T) {
+	tests := []struct {
+		data string
+		ok   bool
+	}{
+		{data: `{"rarity_weights":{"common":3,"rare":1},"cards":[{"name":"A","rarity":"common"},{"name":"B","rarity":"rare"}]}`, ok: true},
+		{data: `{"rarity_weights":{"common":3},"cards":[{"name":"A","rarity":"common"},{"name":"A","rarity":"common"}]}`, ok: false},
+		{data: `{"rarity_weights":{"common":3},"cards":[{"name":"A","rarity":"rare"}]}`, ok: false},
+		{data: `{"rarity_weights":{"common":3},"cards":[]}`, ok: false},
+		{data: `not json`, ok: false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, err := ParseCatalogue([]byte(test.data))
+			assert.Equal(t, test.ok, err == nil)
+		})
+	}
+}
+
+func TestCatalogueDraw(t *testing.T) {
+	c, err := ParseCatalogue([]byte(`{"rarity_weights":{"common":9,"legendary":1},"cards":[{"name":"A","rarity":"common"},{"name":"B","rarity":"legendary"}]}`))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.9, c.Probability(c.Cards[0]), 1e-9)
+
+	counts := map[string]int{}
+	dice := NewDice(1)
+	for i := 0; i < 10000; i++ {
+		counts[c.Draw(dice).Name]++
+	}
+	assert.InDelta(t, 9000, counts["A"], 300)
+	assert.InDelta(t, 1000, counts["B"], 300)
+
+	card, ok := c.Find("B")
+	assert.True(t, ok)
+	assert.Equal(t, CardRarityLegendary, card.Rarity)
+	_, ok = c.Find("C")
+	assert.False(t, ok)
+}
+
+func TestLoadDefaultCatalogue(t *testing.T) {
+	assert.Error(t, LoadDefaultCatalogue([]byte(`not json`)))
+	assert.NoError(t, LoadDefaultCatalogue([]byte(`{"rarity_weights":{"common":1},"cards":[{"name":"A","rarity":"common"}]}`)))
+	_, ok := DefaultCatalogue().Find("A")
+	assert.True(t, ok)
+	assert.Equal(t, "A", DrawCard().Name)
+}
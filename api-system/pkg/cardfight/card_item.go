@@ -10,14 +10,45 @@ const (
 	CardItemJet
 )
 
+// 卡牌的兵种
+const (
+	CardClassTank     = "tank"
+	CardClassSPAA     = "spaa"
+	CardClassFighter  = "fighter"
+	CardClassAttacker = "attacker"
+)
+
+var cardClassNames = map[string]string{
+	CardClassTank:     "坦克",
+	CardClassSPAA:     "防空车",
+	CardClassFighter:  "战斗机",
+	CardClassAttacker: "攻击机",
+}
+
+// ClassName 兵种的中文名称，未知的兵种视为坦克
+func ClassName(class string) string {
+	if name, ok := cardClassNames[class]; ok {
+		return name
+	}
+	return cardClassNames[CardClassTank]
+}
+
 type CardItem struct {
 	name string
 	// 用户名称
 	user string
 	// 卡牌类型
 	cardType int
+	// 兵种
+	class string
 	// 成员熟练度
 	memberProficiency float64
+	// 对战中使用的随机数来源，由对战统一设置
+	dice *Dice
+}
+
+func (i *CardItem) setDice(dice *Dice) {
+	i.dice = dice
 }
 
 func (i *CardItem) displayName() string {
@@ -32,10 +63,6 @@ func (i *CardItem) displayName() string {
 	return fmt.Sprintf("%s(%s)", i.name, shortName)
 }
 
-func (i *CardItem) TakeStepWithCar(item *CardCarItem) string {
-	return ""
-}
-
 func (i *CardItem) IsDead() (bool, string) {
 	return true, ""
 }
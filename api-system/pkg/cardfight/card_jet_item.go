@@ -0,0 +1,232 @@
+package cardfight
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type CardJetItem struct {
+	CardItem
+	// 机动
+	velocity float64
+	// 火力
+	firepower float64
+	// 防护
+	protection float64
+	// 飞机模块
+	module CardJetModule
+}
+
+type CardJetModule struct {
+	// 飞行员
+	Pilot float64
+	// 引擎
+	Engine float64
+	// 机翼
+	Wings float64
+	// 舵面
+	ControlSurface float64
+	// 油箱
+	FuelTank float64
+}
+
+// InitJetItem 飞机卡牌，class为战斗机或攻击机。战斗机擅长空战，攻击机擅长对地攻击
+func InitJetItem(name string, class string,
+	user string, memberProficiency float64,
+	velocity float64, firepower float64, protection float64) *CardJetItem {
+	return &CardJetItem{
+		CardItem: CardItem{
+			name:              name,
+			user:              user,
+			cardType:          CardItemJet,
+			class:             class,
+			memberProficiency: memberProficiency,
+		},
+		velocity:   velocity,
+		firepower:  firepower,
+		protection: protection,
+		module: CardJetModule{
+			Pilot:          100,
+			Engine:         100,
+			Wings:          100,
+			ControlSurface: 100,
+			FuelTank:       100,
+		},
+	}
+}
+
+func (i *CardJetItem) Velocity() float64 {
+	return i.velocity
+}
+
+// TakeStep 根据敌方卡牌的类型进行空战或者对地攻击
+func (i *CardJetItem) TakeStep(enemy CardAction) []FightEvent {
+	if i.module.Engine <= 33 {
+		return []FightEvent{i.recover("由于引擎故障无法攻击，"), newStatusEvent(i)}
+	}
+	if i.module.ControlSurface <= 33 {
+		return []FightEvent{i.recover("由于舵面受损无法攻击，"), newStatusEvent(i)}
+	}
+	switch e := enemy.(type) {
+	case *CardJetItem:
+		return []FightEvent{i.dogfight(e), newStatusEvent(e)}
+	case *CardCarItem:
+		return []FightEvent{i.strike(e), newStatusEvent(e)}
+	}
+	return nil
+}
+
+func (i *CardJetItem) IsDead() (bool, string) {
+	if i.module.Pilot <= 33 {
+		msg := "%s 飞行员失去意识，坠毁了"
+		return true, fmt.Sprintf(msg, i.displayName())
+	}
+	if i.module.FuelTank <= 0 {
+		msg := "%s 油箱起火爆炸！"
+		return true, fmt.Sprintf(msg, i.displayName())
+	}
+	if i.module.Wings <= 0 {
+		msg := "%s 机翼断裂，坠毁了"
+		return true, fmt.Sprintf(msg, i.displayName())
+	}
+	return false, ""
+}
+
+func (i *CardJetItem) ModuleStatus() string {
+	res := make([]string, 0)
+	module := i.module
+	if module.FuelTank < 100 {
+		res = append(res, fmt.Sprintf("油箱（%.2f%%）", math.Max(module.FuelTank, 0)))
+	}
+	if module.Pilot < 100 {
+		res = append(res, fmt.Sprintf("飞行员（%.2f%%）", math.Max(module.Pilot, 0)))
+	}
+	if module.Wings < 100 {
+		res = append(res, fmt.Sprintf("机翼（%.2f%%）", math.Max(module.Wings, 0)))
+	}
+	if module.ControlSurface < 100 {
+		res = append(res, fmt.Sprintf("舵面（%.2f%%）", math.Max(module.ControlSurface, 0)))
+	}
+	if module.Engine < 100 {
+		res = append(res, fmt.Sprintf("引擎（%.2f%%）", math.Max(module.Engine, 0)))
+	}
+	if len(res) > 0 {
+		return fmt.Sprintf("%s 状态：%s", i.displayName(), strings.Join(res, "，"))
+	} else {
+		return fmt.Sprintf("%s 完好无损", i.displayName())
+	}
+}
+
+// dogfight 空战，战斗机有额外的伤害
+func (i *CardJetItem) dogfight(enemy *CardJetItem) FightEvent {
+	baseDamage := 40.0
+	if i.class == CardClassFighter {
+		baseDamage *= 1.2
+	} else {
+		baseDamage *= 0.8
+	}
+	damages := attackJetModules(i.dice, baseDamage, i.firepower, enemy.protection, enemy.velocity, &enemy.module)
+	msgTemplate := "%s 与 %s 展开缠斗，%s"
+	return FightEvent{
+		Type:    FightEventAttack,
+		Actor:   i.displayName(),
+		Target:  enemy.displayName(),
+		Damages: damages,
+		Text:    fmt.Sprintf(msgTemplate, i.displayName(), enemy.displayName(), describeDamages(damages, "未能命中")),
+	}
+}
+
+// strike 对地攻击，攻击机有额外的伤害，地面载具难以躲避空中的攻击
+func (i *CardJetItem) strike(enemy *CardCarItem) FightEvent {
+	baseDamage := 40.0
+	if i.class == CardClassAttacker {
+		baseDamage *= 1.3
+	} else {
+		baseDamage *= 0.8
+	}
+	damages := attackCarModules(i.dice, baseDamage, i.firepower, enemy.protection, enemy.velocity*0.5, &enemy.module)
+	msgTemplate := "%s 对 %s 进行对地攻击，%s"
+	return FightEvent{
+		Type:    FightEventAttack,
+		Actor:   i.displayName(),
+		Target:  enemy.displayName(),
+		Damages: damages,
+		Text:    fmt.Sprintf(msgTemplate, i.displayName(), enemy.displayName(), describeDamages(damages, "未能击穿他们的装甲")),
+	}
+}
+
+// recover 无法攻击时调整飞行姿态，reason为无法攻击的原因
+func (i *CardJetItem) recover(reason string) FightEvent {
+	repairs := recoverJetModules(30, i.memberProficiency, &i.module)
+	msgTemplate := "%s%s 调整了飞行姿态，恢复了 %s"
+	return FightEvent{
+		Type:    FightEventRepair,
+		Actor:   i.displayName(),
+		Repairs: repairs,
+		Text:    fmt.Sprintf(msgTemplate, reason, i.displayName(), strings.Join(repairs, "，")),
+	}
+}
+
+func attackJetModules(dice *Dice, baseDamage float64, attack float64, defend float64, dodge float64, module *CardJetModule) []ModuleDamage {
+	res := make([]ModuleDamage, 0)
+	baseProb := 0.4
+	prob := baseProb * (2 - dodge*0.1)
+	if dice.CalProbabilities(prob * 0.2) {
+		if dice.CalProbabilities(0.5) {
+			module.FuelTank -= 100
+			return append(res, ModuleDamage{Module: "油箱", Damage: 100, Critical: true})
+		}
+		damage := calDamage(dice, baseDamage, attack, defend)
+		module.FuelTank -= damage
+		res = append(res, ModuleDamage{Module: "油箱", Damage: damage})
+	}
+
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
+		module.Pilot -= damage
+		res = append(res, ModuleDamage{Module: "飞行员", Damage: damage})
+	}
+	if dice.CalProbabilities(prob * 1.1) {
+		damage := calDamage(dice, baseDamage, attack, defend)
+		module.Wings -= damage
+		res = append(res, ModuleDamage{Module: "机翼", Damage: damage})
+	}
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
+		module.ControlSurface -= damage
+		res = append(res, ModuleDamage{Module: "舵面", Damage: damage})
+	}
+	if dice.CalProbabilities(prob) {
+		damage := calDamage(dice, baseDamage, attack, defend)
+		module.Engine -= damage
+		res = append(res, ModuleDamage{Module: "引擎", Damage: damage})
+	}
+	return res
+}
+
+// recoverJetModules 飞行中无法维修机翼和油箱，只能恢复飞行员状态以及调整引擎和舵面
+func recoverJetModules(baseRepair float64, Proficiency float64, module *CardJetModule) []string {
+	res := make([]string, 0)
+	repair := baseRepair * (0.1 * Proficiency)
+
+	if repair > 0 {
+		f := math.Min(100-module.Pilot, repair)
+		module.Pilot += f
+		repair -= f
+		res = append(res, "飞行员")
+	}
+	if repair > 0 {
+		f := math.Min(100-module.Engine, repair)
+		module.Engine += f
+		repair -= f
+		res = append(res, "引擎")
+	}
+	if repair > 0 {
+		f := math.Min(100-module.ControlSurface, repair)
+		module.ControlSurface += f
+		repair -= f
+		res = append(res, "舵面")
+	}
+	return res
+}
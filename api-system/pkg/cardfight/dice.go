@@ -0,0 +1,40 @@
+package cardfight
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	mrand "math/rand"
+)
+
+// Dice 对战中使用的随机数来源，相同的种子会得到相同的随机数序列
+type Dice struct {
+	r *mrand.Rand
+}
+
+func NewDice(seed int64) *Dice {
+	return &Dice{r: mrand.New(mrand.NewSource(seed))}
+}
+
+// NewSeed 生成一个新的随机数种子
+func NewSeed() int64 {
+	n, _ := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	return n.Int64()
+}
+
+func (d *Dice) CalProbabilities(percent float64) bool {
+	if 0 > percent || percent > 1000 {
+		return false
+	}
+	intn := d.r.Int63n(1000)
+	return intn <= int64(math.Floor(percent*1000.0))
+}
+
+func (d *Dice) Float64() float64 {
+	return d.r.Float64()
+}
+
+// Intn 返回[0, n)内的随机数
+func (d *Dice) Intn(n int) int {
+	return d.r.Intn(n)
+}
@@ -0,0 +1,76 @@
+package cardfight
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 对战事件的类型
+const (
+	FightEventStart  = "start"
+	FightEventFirst  = "first"
+	FightEventAttack = "attack"
+	FightEventRepair = "repair"
+	FightEventStatus = "status"
+	// FightEventDestroyed 团战中有卡牌被击毁，对战仍在继续
+	FightEventDestroyed = "destroyed"
+	FightEventOutcome   = "outcome"
+)
+
+// ModuleDamage 一次攻击对某个模块造成的伤害
+type ModuleDamage struct {
+	Module   string  `json:"module"`
+	Damage   float64 `json:"damage"`
+	Critical bool    `json:"critical,omitempty"`
+}
+
+// FightEvent 对战过程中的一个事件，Text为展示给用户的文字
+type FightEvent struct {
+	// 回合数，入场和先手事件为0
+	Turn    int            `json:"turn"`
+	Type    string         `json:"type"`
+	Actor   string         `json:"actor"`
+	Target  string         `json:"target,omitempty"`
+	Damages []ModuleDamage `json:"damages,omitempty"`
+	Repairs []string       `json:"repairs,omitempty"`
+	Text    string         `json:"text"`
+}
+
+// FightResult 一场对战的完整记录，使用相同的卡牌和Seed可以重新生成
+type FightResult struct {
+	Seed    int64        `json:"seed"`
+	Outcome int          `json:"outcome"`
+	Events  []FightEvent `json:"events"`
+}
+
+// Steps 对战过程的文字描述
+func (r FightResult) Steps() []string {
+	res := make([]string, 0, len(r.Events))
+	for _, event := range r.Events {
+		res = append(res, event.Text)
+	}
+	return res
+}
+
+func newStatusEvent(item CardAction) FightEvent {
+	return FightEvent{
+		Type:  FightEventStatus,
+		Actor: item.displayName(),
+		Text:  item.ModuleStatus(),
+	}
+}
+
+// describeDamages 伤害的文字描述，暴击时只会有一项伤害
+func describeDamages(damages []ModuleDamage, missText string) string {
+	if len(damages) == 0 {
+		return missText
+	}
+	if len(damages) == 1 && damages[0].Critical {
+		return fmt.Sprintf("%s（-100%% 暴击！）", damages[0].Module)
+	}
+	res := make([]string, 0, len(damages))
+	for _, damage := range damages {
+		res = append(res, fmt.Sprintf("%s（-%.2f%%）", damage.Module, damage.Damage))
+	}
+	return "对其造成了 " + strings.Join(res, "，")
+}
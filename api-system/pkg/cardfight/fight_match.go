@@ -1,56 +1,111 @@
 package cardfight
 
 import (
-	"crypto/rand"
 	"fmt"
-	"math"
-	"math/big"
 )
 
+// FightMatch 任意两张卡牌之间的对战，对战过程会修改卡牌的模块状态
 type FightMatch struct {
-	A CardCarItem
-	B CardCarItem
+	A CardAction
+	B CardAction
+	// Seed 随机数种子，相同的卡牌和种子会得到相同的对战过程
+	Seed int64
+	// MaxTurn 最大回合数，为0时使用默认的回合数
+	MaxTurn int
+}
+
+// 对战结果
+const (
+	FightResultDraw = iota
+	FightResultAWin
+	FightResultBWin
+)
+
+// fightMaxTurn 默认的最大回合数
+const fightMaxTurn = 10
+
+func maxTurnOrDefault(maxTurn int) int {
+	if maxTurn <= 0 {
+		return fightMaxTurn
+	}
+	return maxTurn
 }
 
 func (m FightMatch) Fight() []string {
-	res := make([]string, 0)
-	res = append(res, m.A.StartFight())
-	res = append(res, m.B.StartFight())
-	fst, sec := m.decideOrderBothCar()
-	res = append(res, fmt.Sprintf("%s 取得了先手！", fst.displayName()))
-	maxStep := 10
+	return m.FightWithResult().Steps()
+}
+
+// FightWithResult 进行对战，返回包含全部事件的对战记录
+func (m FightMatch) FightWithResult() FightResult {
+	dice := NewDice(m.Seed)
+	m.A.setDice(dice)
+	m.B.setDice(dice)
+	result := FightResult{Seed: m.Seed, Outcome: FightResultDraw}
+	addEvents := func(turn int, events ...FightEvent) {
+		for _, event := range events {
+			event.Turn = turn
+			result.Events = append(result.Events, event)
+		}
+	}
+
+	addEvents(0, newStartEvent(m.A), newStartEvent(m.B))
+	fst, sec := m.decideOrder()
+	addEvents(0, FightEvent{
+		Type:  FightEventFirst,
+		Actor: fst.displayName(),
+		Text:  fmt.Sprintf("%s 取得了先手！", fst.displayName()),
+	})
 
-	for maxStep > 0 {
-		serverDown := CalProbabilities(0.01)
+	maxTurn := maxTurnOrDefault(m.MaxTurn)
+	finished := false
+	for turn := 1; turn <= maxTurn; turn++ {
+		serverDown := dice.CalProbabilities(0.01)
 		if serverDown {
-			res = append(res, "====服务器已断开连接====")
+			addEvents(turn, FightEvent{Type: FightEventOutcome, Text: "====服务器已断开连接===="})
+			finished = true
 			break
 		}
-		res = append(res, fst.TakeStepWithCar(sec)...)
+		addEvents(turn, fst.TakeStep(sec)...)
 		if dead, s := sec.IsDead(); dead {
-			res = append(res, s)
+			addEvents(turn, FightEvent{Type: FightEventOutcome, Actor: sec.displayName(), Text: s})
+			result.Outcome = m.resultOfWinner(fst)
+			finished = true
 			break
 		}
-		//res = append(res, sec.ModuleStatus())
-		res = append(res, sec.TakeStepWithCar(fst)...)
+		addEvents(turn, sec.TakeStep(fst)...)
 		if dead, s := fst.IsDead(); dead {
-			res = append(res, s)
+			addEvents(turn, FightEvent{Type: FightEventOutcome, Actor: fst.displayName(), Text: s})
+			result.Outcome = m.resultOfWinner(sec)
+			finished = true
 			break
 		}
-		//res = append(res, fst.ModuleStatus())
-		maxStep--
 	}
-	if maxStep == 0 {
-		res = append(res, "对战结束，未分出胜负")
+	if !finished {
+		addEvents(maxTurn, FightEvent{Type: FightEventOutcome, Text: "对战结束，未分出胜负"})
 	}
-	return res
+	return result
+}
+
+func newStartEvent(item CardAction) FightEvent {
+	return FightEvent{
+		Type:  FightEventStart,
+		Actor: item.displayName(),
+		Text:  item.StartFight(),
+	}
+}
+
+func (m FightMatch) resultOfWinner(winner CardAction) int {
+	if winner == m.A {
+		return FightResultAWin
+	}
+	return FightResultBWin
 }
 
-func (m FightMatch) decideOrderBothCar() (*CardCarItem, *CardCarItem) {
-	if m.A.velocity > m.B.velocity {
-		return &m.A, &m.B
+func (m FightMatch) decideOrder() (CardAction, CardAction) {
+	if m.A.Velocity() > m.B.Velocity() {
+		return m.A, m.B
 	} else {
-		return &m.B, &m.A
+		return m.B, m.A
 	}
 }
 
@@ -61,12 +116,3 @@ func GenerateFightText(steps []string) string {
 	}
 	return res
 }
-
-func CalProbabilities(percent float64) bool {
-	if 0 > percent || percent > 1000 {
-		return false
-	}
-	n, _ := rand.Int(rand.Reader, big.NewInt(1000))
-	intn := n.Int64()
-	return intn <= int64(math.Floor(percent*1000.0))
-}
@@ -0,0 +1,116 @@
+package cardfight
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func newTestCards() [][2]Card {
+	tank := Card{Name: "ZTZ99A", Class: CardClassTank, Velocity: 9, Firepower: 7, Protection: 6}
+	spaa := Card{Name: "猎豹防空炮", Class: CardClassSPAA, Velocity: 7, Firepower: 7, Protection: 3}
+	fighter := Card{Name: "歼-10A", Class: CardClassFighter, Velocity: 9, Firepower: 7, Protection: 3}
+	attacker := Card{Name: "A-10A", Class: CardClassAttacker, Velocity: 5, Firepower: 9, Protection: 6}
+	return [][2]Card{
+		{tank, tank},
+		{fighter, attacker},
+		{attacker, tank},
+		{spaa, fighter},
+	}
+}
+
+func TestFightMatchReplay(t *testing.T) {
+	for i, pair := range newTestCards() {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			for seed := int64(0); seed < 50; seed++ {
+				fight := func() FightResult {
+					return FightMatch{
+						A:    pair[0].NewItem("用户一号", 10),
+						B:    pair[1].NewItem("用户二号", 10),
+						Seed: seed,
+					}.FightWithResult()
+				}
+				first := fight()
+				assert.Equal(t, first, fight())
+				assert.Equal(t, seed, first.Seed)
+				assert.Equal(t, FightEventOutcome, first.Events[len(first.Events)-1].Type)
+			}
+		})
+	}
+}
+
+func TestFightMatchTankCanNotAttackJet(t *testing.T) {
+	tank := Card{Name: "ZTZ99A", Class: CardClassTank, Velocity: 9, Firepower: 7, Protection: 6}
+	jet := Card{Name: "A-10A", Class: CardClassAttacker, Velocity: 5, Firepower: 9, Protection: 6}
+	for seed := int64(0); seed < 50; seed++ {
+		a := tank.NewItem("用户一号", 10)
+		result := FightMatch{A: a, B: jet.NewItem("用户二号", 10), Seed: seed}.FightWithResult()
+		assert.NotEqual(t, FightResultAWin, result.Outcome)
+		for _, event := range result.Events {
+			if event.Actor == a.displayName() {
+				assert.NotEqual(t, FightEventAttack, event.Type)
+			}
+		}
+	}
+}
+
+func TestDescribeDamages(t *testing.T) {
+	tests := []struct {
+		damages []ModuleDamage
+		want    string
+	}{
+		{damages: nil, want: "未能命中"},
+		{damages: []ModuleDamage{{Module: "弹药架", Damage: 100, Critical: true}}, want: "弹药架（-100% 暴击！）"},
+		{damages: []ModuleDamage{{Module: "成员", Damage: 12.345}, {Module: "履带", Damage: 40}}, want: "对其造成了 成员（-12.35%），履带（-40.00%）"},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, describeDamages(tt.damages, "未能命中"))
+		})
+	}
+}
+
+func TestTeamMatch(t *testing.T) {
+	cards := newTestCards()
+	newTeam := func(user string) []CardAction {
+		team := make([]CardAction, 0)
+		for _, pair := range cards {
+			team = append(team, pair[0].NewItem(user, 10))
+		}
+		return team
+	}
+	for seed := int64(0); seed < 50; seed++ {
+		fight := func() FightResult {
+			return TeamMatch{A: newTeam("用户一号"), B: newTeam("用户二号"), Seed: seed}.FightWithResult()
+		}
+		first := fight()
+		assert.Equal(t, first, fight())
+		last := first.Events[len(first.Events)-1]
+		assert.Equal(t, FightEventOutcome, last.Type)
+		assert.LessOrEqual(t, last.Turn, fightMaxTurn)
+	}
+}
+
+func TestTargetPriority(t *testing.T) {
+	tank := InitCarItem("ZTZ99A", "a", 10, 9, 7, 6)
+	spaa := InitSPAAItem("猎豹防空炮", "a", 10, 7, 7, 3)
+	fighter := InitJetItem("歼-10A", CardClassFighter, "a", 10, 9, 7, 3)
+	attacker := InitJetItem("A-10A", CardClassAttacker, "a", 10, 5, 9, 6)
+	tests := []struct {
+		attacker CardAction
+		target   CardAction
+		want     int
+	}{
+		{attacker: tank, target: tank, want: 1},
+		{attacker: tank, target: fighter, want: 0},
+		{attacker: spaa, target: fighter, want: 2},
+		{attacker: fighter, target: attacker, want: 2},
+		{attacker: fighter, target: tank, want: 1},
+		{attacker: attacker, target: spaa, want: 2},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, targetPriority(tt.attacker, tt.target))
+		})
+	}
+}
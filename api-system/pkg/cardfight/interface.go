@@ -1,22 +1,34 @@
 package cardfight
 
+// CardAction 可以参与对战的卡牌
 type CardAction interface {
 	displayName() string
+	setDice(dice *Dice)
 
-	TakeStepWithCar(item *CardCarItem) string
 	// StartFight 入场介绍
 	StartFight() string
+	// Velocity 机动性，机动性高的一方取得先手
+	Velocity() float64
+	// TakeStep 对敌方进行一回合的行动
+	TakeStep(enemy CardAction) []FightEvent
 	IsDead() (bool, string)
 	ModuleStatus() string
 }
 
 type CardCarAction interface {
 	CardAction
-	attack(enemy *CardCarItem) string
-	repair() string
+	attack(enemy *CardCarItem) FightEvent
+	repair(reason string) FightEvent
+}
+
+type CardJetAction interface {
+	CardAction
+	dogfight(enemy *CardJetItem) FightEvent
+	strike(enemy *CardCarItem) FightEvent
+	recover(reason string) FightEvent
 }
 
 type MatchAction interface {
-	decideOrderBothCar() (*CardCarItem, *CardCarItem)
+	decideOrder() (CardAction, CardAction)
 	Fight() []string
 }
@@ -0,0 +1,145 @@
+package cardfight
+
+import (
+	"sort"
+)
+
+// TeamMatch 两队卡牌之间的排级对战，A为挑战方，B为应战方。
+// 每回合所有存活的卡牌按机动性依次行动，每次行动前选择一个目标
+type TeamMatch struct {
+	A []CardAction
+	B []CardAction
+	// Seed 随机数种子，相同的卡牌和种子会得到相同的对战过程
+	Seed int64
+	// MaxTurn 最大回合数，为0时使用默认的回合数
+	MaxTurn int
+}
+
+type teamMember struct {
+	item  CardAction
+	teamA bool
+	dead  bool
+}
+
+// FightWithResult 进行团战，一方的卡牌全部被击毁时另一方获胜
+func (m TeamMatch) FightWithResult() FightResult {
+	dice := NewDice(m.Seed)
+	result := FightResult{Seed: m.Seed, Outcome: FightResultDraw}
+	addEvents := func(turn int, events ...FightEvent) {
+		for _, event := range events {
+			event.Turn = turn
+			result.Events = append(result.Events, event)
+		}
+	}
+
+	members := make([]*teamMember, 0, len(m.A)+len(m.B))
+	for _, item := range m.A {
+		members = append(members, &teamMember{item: item, teamA: true})
+	}
+	for _, item := range m.B {
+		members = append(members, &teamMember{item: item})
+	}
+	for _, member := range members {
+		member.item.setDice(dice)
+		addEvents(0, newStartEvent(member.item))
+	}
+	if outcome, text, ok := teamOutcome(members); ok {
+		result.Outcome = outcome
+		addEvents(0, FightEvent{Type: FightEventOutcome, Text: text})
+		return result
+	}
+
+	// 机动性高的先行动，相同时挑战方先行动
+	order := make([]*teamMember, len(members))
+	copy(order, members)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].item.Velocity() > order[j].item.Velocity()
+	})
+
+	maxTurn := maxTurnOrDefault(m.MaxTurn)
+	for turn := 1; turn <= maxTurn; turn++ {
+		for _, member := range order {
+			if member.dead {
+				continue
+			}
+			target := selectTarget(dice, member, members)
+			addEvents(turn, member.item.TakeStep(target.item)...)
+			if dead, s := target.item.IsDead(); dead {
+				target.dead = true
+				addEvents(turn, FightEvent{Type: FightEventDestroyed, Actor: target.item.displayName(), Text: s})
+			}
+			if outcome, text, ok := teamOutcome(members); ok {
+				result.Outcome = outcome
+				addEvents(turn, FightEvent{Type: FightEventOutcome, Text: text})
+				return result
+			}
+		}
+	}
+	addEvents(maxTurn, FightEvent{Type: FightEventOutcome, Text: "对战结束，未分出胜负"})
+	return result
+}
+
+// teamOutcome 判断团战是否已经分出胜负
+func teamOutcome(members []*teamMember) (int, string, bool) {
+	aliveA, aliveB := 0, 0
+	for _, member := range members {
+		if member.dead {
+			continue
+		}
+		if member.teamA {
+			aliveA++
+		} else {
+			aliveB++
+		}
+	}
+	switch {
+	case aliveA == 0 && aliveB == 0:
+		return FightResultDraw, "双方全部被击毁，未分出胜负", true
+	case aliveB == 0:
+		return FightResultAWin, "应战方全部被击毁，挑战方获胜！", true
+	case aliveA == 0:
+		return FightResultBWin, "挑战方全部被击毁，应战方获胜！", true
+	}
+	return FightResultDraw, "", false
+}
+
+// selectTarget 在存活的敌方卡牌中选择优先级最高的目标，优先级相同时随机选择
+func selectTarget(dice *Dice, attacker *teamMember, members []*teamMember) *teamMember {
+	var candidates []*teamMember
+	best := -1
+	for _, member := range members {
+		if member.dead || member.teamA == attacker.teamA {
+			continue
+		}
+		priority := targetPriority(attacker.item, member.item)
+		if priority > best {
+			best = priority
+			candidates = candidates[:0]
+		}
+		if priority == best {
+			candidates = append(candidates, member)
+		}
+	}
+	return candidates[dice.Intn(len(candidates))]
+}
+
+// targetPriority 攻击方对目标的偏好：坦克无法攻击飞机，防空车和战斗机优先攻击飞机，攻击机优先攻击地面载具
+func targetPriority(attacker CardAction, target CardAction) int {
+	_, targetIsJet := target.(*CardJetItem)
+	switch a := attacker.(type) {
+	case *CardCarItem:
+		if !targetIsJet {
+			return 1
+		}
+		if a.class == CardClassSPAA {
+			return 2
+		}
+		return 0
+	case *CardJetItem:
+		if (a.class == CardClassFighter) == targetIsJet {
+			return 2
+		}
+		return 1
+	}
+	return 1
+}
@@ -0,0 +1,51 @@
+package cqhttp
+
+import (
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+)
+
+// Adapter go-cqhttp 的机器人平台适配器
+type Adapter struct{}
+
+func (a Adapter) Platform() string {
+	return bot.PlatformCqHttp
+}
+
+func (a Adapter) Mention(userId int64) string {
+	return fmt.Sprintf("[CQ:at,qq=%d] ", userId)
+}
+
+func (a Adapter) Send(reply bot.Reply) error {
+	form := SendGroupMsgForm{
+		SelfId:        reply.SelfId,
+		MessagePrefix: reply.MessagePrefix,
+		GroupId:       reply.GroupId,
+		UserId:        reply.UserId,
+		Message:       reply.Message,
+	}
+	if reply.IsPrivate() {
+		form.MessageType = MessageTypePrivate
+	} else {
+		form.MessageType = MessageTypeGroup
+	}
+	return SendMsg(form)
+}
+
+// ToBotMessage 将消息事件转换为平台无关的消息
+func (m *CommonEvent) ToBotMessage() bot.Message {
+	msg := bot.Message{
+		Platform: bot.PlatformCqHttp,
+		SelfId:   m.SelfId,
+		GroupId:  m.GroupId,
+		UserId:   m.UserId,
+		Role:     m.Sender.Role,
+		Content:  m.Message,
+	}
+	if m.MessageType == MessageTypePrivate {
+		msg.MessageType = bot.MessageTypePrivate
+	} else {
+		msg.MessageType = bot.MessageTypeGroup
+	}
+	return msg
+}
@@ -1,58 +1,107 @@
 package cqhttp
 
 import (
+	"fmt"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 	"github.com/axiangcoding/antonstar-bot/setting"
 	"github.com/go-resty/resty/v2"
 	"time"
 )
 
-// MustSendGroupMsg
+// CallApi 使用指定的qq账号调用cqhttp的api，selfId为0时使用默认账号。
+// 已经建立反向websocket连接时优先通过websocket调用，否则通过http api调用
 // TODO 将配置项外移，不在最终方法中调用
-// https://docs.go-cqhttp.org/api/#%E5%A4%84%E7%90%86%E5%8A%A0%E7%BE%A4%E8%AF%B7%E6%B1%82-%E9%82%80%E8%AF%B7
-func MustSendGroupMsg(form SendGroupMsgForm) {
-	url := setting.C().App.Service.CqHttp.Url + "/send_group_msg"
+func CallApi(selfId int64, action string, params map[string]any) (*ApiResponse, error) {
+	conf, ok := setting.FindCqHttpConf(selfId)
+	if !ok {
+		return nil, fmt.Errorf("cqhttp account %d not configured", selfId)
+	}
+	var resp *ApiResponse
+	var err error
+	if conn, ok := GetWsConn(conf.SelfQQ); ok {
+		resp, err = conn.Call(action, params)
+	} else {
+		resp, err = callHttpApi(conf.Url, action, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == "failed" {
+		return resp, fmt.Errorf("call %s failed, retcode %d", action, resp.Retcode)
+	}
+	return resp, nil
+}
+
+func callHttpApi(baseUrl string, action string, params map[string]any) (*ApiResponse, error) {
+	url := baseUrl + "/" + action
 	client := resty.New().SetTimeout(time.Second * 20)
-	var commonResp CommonResponse
+	var apiResp ApiResponse
 	resp, err := client.R().SetHeader("Content-cardType", "application/json").
-		SetBody(map[string]any{
-			"message":  form.MessagePrefix + form.Message,
-			"group_id": form.GroupId,
-		}).SetResult(&commonResp).Post(url)
+		SetBody(params).SetResult(&apiResp).Post(url)
 	if err != nil {
-		logging.L().Error("send group message error", logging.Error(err))
+		return nil, err
 	}
 	if resp.IsError() {
-		logging.L().Warn("post error",
-			logging.Any("url", url),
-			logging.Any("statusCode", resp.StatusCode()),
-			logging.Any("resp", resp.String()))
+		return nil, fmt.Errorf("post %s error, status code %d, resp %s", url, resp.StatusCode(), resp.String())
 	}
-	if commonResp.Status == "failed" {
-		logging.L().Error("send message failed", logging.Any("resp", commonResp))
+	return &apiResp, nil
+}
+
+// SendGroupMsg
+// https://docs.go-cqhttp.org/api/#%E5%8F%91%E9%80%81%E7%BE%A4%E6%B6%88%E6%81%AF
+func SendGroupMsg(form SendGroupMsgForm) error {
+	_, err := CallApi(form.SelfId, "send_group_msg", map[string]any{
+		"message":  form.MessagePrefix + form.Message,
+		"group_id": form.GroupId,
+	})
+	return err
+}
+
+func MustSendGroupMsg(form SendGroupMsgForm) {
+	if err := SendGroupMsg(form); err != nil {
+		logging.L().Error("send group message error", logging.Error(err))
+	}
+}
+
+// SendPrivateMsg
+// https://docs.go-cqhttp.org/api/#%E5%8F%91%E9%80%81%E7%A7%81%E8%81%8A%E6%B6%88%E6%81%AF
+func SendPrivateMsg(form SendGroupMsgForm) error {
+	_, err := CallApi(form.SelfId, "send_private_msg", map[string]any{
+		"message": form.MessagePrefix + form.Message,
+		"user_id": form.UserId,
+	})
+	return err
+}
+
+func MustSendPrivateMsg(form SendGroupMsgForm) {
+	if err := SendPrivateMsg(form); err != nil {
+		logging.L().Error("send private message error", logging.Error(err))
+	}
+}
+
+// SendMsg 根据 MessageType 选择发送群聊或私聊消息，未设置时按群聊处理
+func SendMsg(form SendGroupMsgForm) error {
+	if form.MessageType == MessageTypePrivate {
+		return SendPrivateMsg(form)
+	}
+	return SendGroupMsg(form)
+}
+
+func MustSendMsg(form SendGroupMsgForm) {
+	if err := SendMsg(form); err != nil {
+		logging.L().Error("send message error", logging.Error(err))
 	}
 }
 
 // MustAcceptInviteToGroup
-// TODO 将配置项外移，不在最终方法中调用
 // https://docs.go-cqhttp.org/api/#%E5%A4%84%E7%90%86%E5%8A%A0%E7%BE%A4%E8%AF%B7%E6%B1%82-%E9%82%80%E8%AF%B7
-func MustAcceptInviteToGroup(flag string, subType string, approve bool, reason string) {
-	url := setting.C().App.Service.CqHttp.Url + "/set_group_add_request"
-	client := resty.New().SetTimeout(time.Second * 20)
-	resp, err := client.R().SetHeader("Content-cardType", "application/json").
-		SetBody(map[string]any{
-			"flag":     flag,
-			"sub_type": subType,
-			"approve":  approve,
-			"reason":   reason,
-		}).Post(url)
-	if err != nil {
+func MustAcceptInviteToGroup(selfId int64, flag string, subType string, approve bool, reason string) {
+	if _, err := CallApi(selfId, "set_group_add_request", map[string]any{
+		"flag":     flag,
+		"sub_type": subType,
+		"approve":  approve,
+		"reason":   reason,
+	}); err != nil {
 		logging.L().Error("send group add request error", logging.Error(err))
 	}
-	if resp.IsError() {
-		logging.L().Warn("post error",
-			logging.Any("url", url),
-			logging.Any("statusCode", resp.StatusCode()),
-			logging.Any("resp", resp.String()))
-	}
 }
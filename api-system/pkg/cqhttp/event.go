@@ -3,17 +3,20 @@ package cqhttp
 import "encoding/json"
 
 var (
-	PostTypeMessage    = "message"
-	PostTypeRequest    = "request"
-	PostTypeNotice     = "notice"
-	PostTypeMetaEvent  = "meta_event"
-	EventTypeHeartBeat = "heartbeat"
-	MessageTypeGroup   = "group"
-	RequestTypeGroup   = "group"
-	RequestTypeFriend  = "friend"
+	PostTypeMessage         = "message"
+	PostTypeRequest         = "request"
+	PostTypeNotice          = "notice"
+	PostTypeMetaEvent       = "meta_event"
+	EventTypeHeartBeat      = "heartbeat"
+	MessageTypeGroup        = "group"
+	MessageTypePrivate      = "private"
+	RequestTypeGroup        = "group"
+	RequestTypeFriend       = "friend"
+	NoticeTypeGroupDecrease = "group_decrease"
 
 	SubTypeAdd    = "add"
 	SubTypeInvite = "invite"
+	SubTypeKickMe = "kick_me"
 )
 
 // MetaTypeHeartBeatEvent 心跳事件
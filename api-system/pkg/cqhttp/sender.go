@@ -1,6 +1,9 @@
 package cqhttp
 
 type SendGroupMsgForm struct {
+	// SelfId 发送消息使用的qq账号，为空时使用默认账号
+	SelfId          int64  `json:"self_id,omitempty"`
+	MessageType     string `json:"message_type,omitempty"`
 	MessagePrefix   string `json:"message_prefix,omitempty"`
 	GroupId         int64  `json:"group_id,omitempty"`
 	UserId          int64  `json:"user_id,omitempty"`
@@ -0,0 +1,158 @@
+package cqhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/gorilla/websocket"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrWsConnClosed  = errors.New("websocket connection closed")
+	ErrWsCallTimeout = errors.New("websocket api call timeout")
+)
+
+// wsCallTimeout 通过websocket调用api时等待响应的最长时间
+const wsCallTimeout = time.Second * 20
+
+// ApiRequest onebot v11 通过websocket调用api的请求体
+type ApiRequest struct {
+	Action string         `json:"action"`
+	Params map[string]any `json:"params"`
+	Echo   string         `json:"echo"`
+}
+
+// ApiResponse onebot v11 api的响应体，通过websocket调用时会带上请求中的echo
+type ApiResponse struct {
+	CommonResponse
+	Data json.RawMessage `json:"data,omitempty"`
+	Echo string          `json:"echo,omitempty"`
+}
+
+// WsConn 一个cqhttp的反向websocket连接，同时承载事件上报和api调用
+type WsConn struct {
+	SelfId  int64
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	echo    atomic.Int64
+	pending sync.Map
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func NewWsConn(selfId int64, conn *websocket.Conn) *WsConn {
+	return &WsConn{
+		SelfId: selfId,
+		conn:   conn,
+		closed: make(chan struct{}),
+	}
+}
+
+// Serve 持续读取连接上的消息，api响应按echo交给等待中的调用方，其余的作为事件交给handler处理，连接断开时返回
+func (w *WsConn) Serve(handler func(data map[string]any)) error {
+	defer w.Close()
+	for {
+		_, body, err := w.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			return err
+		}
+		var data map[string]any
+		if err := json.Unmarshal(body, &data); err != nil {
+			logging.L().Warn("unmarshal websocket message failed", logging.Error(err))
+			continue
+		}
+		// 事件上报一定带有post_type，api响应则带有echo
+		if _, ok := data["post_type"]; !ok {
+			if echo, ok := data["echo"]; ok {
+				w.resolve(fmt.Sprintf("%v", echo), body)
+				continue
+			}
+		}
+		handler(data)
+	}
+}
+
+func (w *WsConn) resolve(echo string, body []byte) {
+	ch, ok := w.pending.LoadAndDelete(echo)
+	if !ok {
+		logging.L().Warn("no pending api call for echo", logging.Any("echo", echo))
+		return
+	}
+	var resp ApiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		logging.L().Warn("unmarshal api response failed", logging.Error(err))
+		return
+	}
+	ch.(chan ApiResponse) <- resp
+}
+
+// Call 通过websocket调用api，并同步等待对应echo的响应
+func (w *WsConn) Call(action string, params map[string]any) (*ApiResponse, error) {
+	echo := strconv.FormatInt(w.echo.Add(1), 10)
+	ch := make(chan ApiResponse, 1)
+	w.pending.Store(echo, ch)
+	defer w.pending.Delete(echo)
+
+	w.writeMu.Lock()
+	err := w.conn.WriteJSON(ApiRequest{Action: action, Params: params, Echo: echo})
+	w.writeMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(wsCallTimeout)
+	defer timer.Stop()
+	select {
+	case resp := <-ch:
+		return &resp, nil
+	case <-w.closed:
+		return nil, ErrWsConnClosed
+	case <-timer.C:
+		return nil, ErrWsCallTimeout
+	}
+}
+
+func (w *WsConn) Close() {
+	w.once.Do(func() {
+		close(w.closed)
+		_ = w.conn.Close()
+	})
+}
+
+var (
+	wsConns   = make(map[int64]*WsConn)
+	wsConnsMu sync.RWMutex
+)
+
+// RegisterWsConn 登记反向websocket连接，同一个账号重复连接时关闭旧连接
+func RegisterWsConn(w *WsConn) {
+	wsConnsMu.Lock()
+	defer wsConnsMu.Unlock()
+	if old, ok := wsConns[w.SelfId]; ok && old != w {
+		old.Close()
+	}
+	wsConns[w.SelfId] = w
+}
+
+func UnregisterWsConn(w *WsConn) {
+	wsConnsMu.Lock()
+	defer wsConnsMu.Unlock()
+	if wsConns[w.SelfId] == w {
+		delete(wsConns, w.SelfId)
+	}
+}
+
+func GetWsConn(selfId int64) (*WsConn, bool) {
+	wsConnsMu.RLock()
+	defer wsConnsMu.RUnlock()
+	w, ok := wsConns[selfId]
+	return w, ok
+}
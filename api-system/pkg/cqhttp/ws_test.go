@@ -0,0 +1,70 @@
+package cqhttp
+
+import (
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newWsPair 启动一个接受反向websocket的服务端，返回服务端的连接以及模拟cqhttp的客户端连接
+func newWsPair(t *testing.T, handler func(data map[string]any)) (*WsConn, *websocket.Conn, func()) {
+	connCh := make(chan *WsConn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		assert.NoError(t, err)
+		wsConn := NewWsConn(10000, conn)
+		connCh <- wsConn
+		_ = wsConn.Serve(handler)
+	}))
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws/", nil)
+	assert.NoError(t, err)
+	return <-connCh, client, func() {
+		_ = client.Close()
+		server.Close()
+	}
+}
+
+func TestWsConnCall(t *testing.T) {
+	events := make(chan map[string]any, 1)
+	wsConn, client, closeFn := newWsPair(t, func(data map[string]any) {
+		events <- data
+	})
+	defer closeFn()
+
+	// 模拟cqhttp：先上报一个事件，再响应收到的api调用
+	go func() {
+		_ = client.WriteJSON(map[string]any{"post_type": "message", "message": ".cqbot 气运"})
+		var req ApiRequest
+		if err := client.ReadJSON(&req); err != nil {
+			return
+		}
+		_ = client.WriteJSON(map[string]any{
+			"status":  "ok",
+			"retcode": 0,
+			"data":    map[string]any{"message_id": 1},
+			"echo":    req.Echo,
+		})
+	}()
+
+	resp, err := wsConn.Call("send_group_msg", map[string]any{"group_id": 1, "message": "你好"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp.Status)
+	assert.JSONEq(t, `{"message_id":1}`, string(resp.Data))
+	assert.Equal(t, ".cqbot 气运", (<-events)["message"])
+}
+
+func TestWsConnCallClosed(t *testing.T) {
+	wsConn, client, closeFn := newWsPair(t, func(data map[string]any) {})
+	defer closeFn()
+
+	go func() {
+		var req ApiRequest
+		_ = client.ReadJSON(&req)
+		_ = client.Close()
+	}()
+	_, err := wsConn.Call("get_status", nil)
+	assert.ErrorIs(t, err, ErrWsConnClosed)
+}
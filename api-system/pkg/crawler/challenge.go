@@ -0,0 +1,38 @@
+package crawler
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+// challengeMarkers cloudflare人机验证页面中的特征
+var challengeMarkers = [][]byte{
+	[]byte("/cdn-cgi/challenge-platform/"),
+	[]byte("cf-chl-"),
+	[]byte("<title>Just a moment...</title>"),
+}
+
+// isBlockedResponse 根据状态码和响应头判断请求是否被拦截或限流
+func isBlockedResponse(statusCode int, header http.Header) bool {
+	if statusCode == http.StatusTooManyRequests || header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+	if statusCode == http.StatusForbidden || statusCode == http.StatusServiceUnavailable {
+		return strings.EqualFold(header.Get("Server"), "cloudflare")
+	}
+	return false
+}
+
+// isChallengeResponse 判断响应是否为cloudflare的人机验证页面，验证页面的状态码也可能是200
+func isChallengeResponse(header http.Header, body []byte) bool {
+	if header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
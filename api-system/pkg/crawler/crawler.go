@@ -2,6 +2,7 @@ package crawler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/axiangcoding/antonstar-bot/internal/data/table"
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
@@ -10,63 +11,153 @@ import (
 	"net/url"
 )
 
+// 爬取的站点地址，测试时可以替换为本地的服务
+var (
+	WTOfficialBaseUrl   = "https://warthunder.com"
+	ThunderskillBaseUrl = "https://thunderskill.com"
+)
+
 const (
 	StatusQueryFailed = 1
 	StatusNotFound    = 2
 	StatusFound       = 3
+	// StatusChallenged 请求被cloudflare的人机验证拦截
+	StatusChallenged = 4
 )
 
-func GetProfileFromWTOfficial(nick string, callback func(status int, user *table.GameUser)) error {
-	urlTemplate := "https://warthunder.com/zh/community/userinfo/?nick=%s"
-	queryUrl := fmt.Sprintf(urlTemplate, url.QueryEscape(nick))
+var ErrChallenged = errors.New("blocked by anti-bot challenge")
 
+// newCollector 创建只允许访问baseUrl所在域名的爬虫
+func newCollector(baseUrl string) *colly.Collector {
+	var domain string
+	if u, err := url.Parse(baseUrl); err == nil {
+		domain = u.Hostname()
+	}
 	c := colly.NewCollector(
-		colly.AllowedDomains("warthunder.com"),
+		colly.AllowedDomains(domain),
 		colly.MaxDepth(1),
 		colly.IgnoreRobotsTxt(),
 	)
+	getNetwork().apply(c)
+	return c
+}
+
+func GetProfileFromWTOfficial(nick string, callback func(status int, user *table.GameUser)) error {
+	urlTemplate := "%s/zh/community/userinfo/?nick=%s"
+	queryUrl := fmt.Sprintf(urlTemplate, WTOfficialBaseUrl, url.QueryEscape(nick))
+
+	c := newCollector(WTOfficialBaseUrl)
 	extensions.RandomUserAgent(c)
 
+	status := StatusQueryFailed
+	var data *table.GameUser
+	challenged := false
 	c.OnHTML("div[class=user__unavailable-title]", func(e *colly.HTMLElement) {
 		logging.L().Warn("WT profile not found", logging.Any("nick", nick))
-		callback(StatusNotFound, nil)
+		status = StatusNotFound
 	})
 
 	c.OnHTML("div[class=user-info]", func(e *colly.HTMLElement) {
-		data := ExtractGaijinData(e)
-		callback(StatusFound, &data)
+		user := ExtractGaijinData(e)
+		data = &user
+		status = StatusFound
 	})
 
 	c.OnRequest(func(r *colly.Request) {
 		logging.L().Info("colly on request", logging.Any("url", r.URL.String()))
 	})
 
+	c.OnResponse(func(r *colly.Response) {
+		challenged = isChallengeResponse(*r.Headers, r.Body)
+	})
+
 	c.OnError(func(r *colly.Response, err error) {
 		logging.L().Warn("colly on error",
 			logging.Any("url", r.Request.URL.String()),
 			logging.Any("statusCode", r.StatusCode))
-		callback(StatusQueryFailed, nil)
-
+		if r.Headers != nil {
+			challenged = isChallengeResponse(*r.Headers, r.Body)
+		}
 	})
 
 	err := c.Post(queryUrl, nil)
-	if err != nil {
+	switch {
+	// 正常页面中也可能注入cloudflare的脚本，只有没有解析到玩家信息时才认为被拦截
+	case challenged && status == StatusQueryFailed:
+		logging.L().Warn("WT profile blocked by challenge", logging.Any("nick", nick))
+		callback(StatusChallenged, nil)
+		return ErrChallenged
+	case err != nil:
 		logging.L().Warn("colly post failed", logging.Error(err))
 		callback(StatusQueryFailed, nil)
 		return err
 	}
+	callback(status, data)
+	return nil
+}
+
+// GetClanFromWTOfficial 爬取战雷官网的联队页面，name为联队页面地址中的联队全称
+func GetClanFromWTOfficial(name string, callback func(status int, clan *table.GameClan, members []table.GameClanMember)) error {
+	urlTemplate := "%s/zh/community/claninfo/%s"
+	queryUrl := fmt.Sprintf(urlTemplate, WTOfficialBaseUrl, url.PathEscape(name))
+
+	c := newCollector(WTOfficialBaseUrl)
+	extensions.RandomUserAgent(c)
+
+	status := StatusQueryFailed
+	var data *table.GameClan
+	var members []table.GameClanMember
+	challenged := false
+	c.OnHTML("body", func(e *colly.HTMLElement) {
+		clan, clanMembers := ExtractGaijinClan(e)
+		if clan.Tag == "" {
+			logging.L().Warn("WT clan not found", logging.Any("name", name))
+			status = StatusNotFound
+			return
+		}
+		clan.Url = queryUrl
+		data = &clan
+		members = clanMembers
+		status = StatusFound
+	})
+
+	c.OnRequest(func(r *colly.Request) {
+		logging.L().Info("colly on request", logging.Any("url", r.URL.String()))
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		challenged = isChallengeResponse(*r.Headers, r.Body)
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		logging.L().Warn("colly on error",
+			logging.Any("url", r.Request.URL.String()),
+			logging.Any("statusCode", r.StatusCode))
+		if r.Headers != nil {
+			challenged = isChallengeResponse(*r.Headers, r.Body)
+		}
+	})
+
+	err := c.Visit(queryUrl)
+	switch {
+	case challenged && status != StatusFound:
+		logging.L().Warn("WT clan blocked by challenge", logging.Any("name", name))
+		callback(StatusChallenged, nil, nil)
+		return ErrChallenged
+	case err != nil:
+		logging.L().Warn("colly visit failed", logging.Error(err))
+		callback(StatusQueryFailed, nil, nil)
+		return err
+	}
+	callback(status, data, members)
 	return nil
 }
 
 func GetProfileFromThunderskill(nick string, callback func(status int, skill *ThunderSkillResp)) error {
-	urlTemplate := "https://thunderskill.com/en/stat/%s/export/json"
-	queryUrl := fmt.Sprintf(urlTemplate, nick)
+	urlTemplate := "%s/en/stat/%s/export/json"
+	queryUrl := fmt.Sprintf(urlTemplate, ThunderskillBaseUrl, nick)
 
-	c := colly.NewCollector(
-		colly.AllowedDomains("thunderskill.com"),
-		colly.MaxDepth(1),
-		colly.IgnoreRobotsTxt(),
-	)
+	c := newCollector(ThunderskillBaseUrl)
 
 	c.OnResponse(func(r *colly.Response) {
 		var resp ThunderSkillResp
@@ -93,12 +184,8 @@ func GetProfileFromThunderskill(nick string, callback func(status int, skill *Th
 }
 
 func GetFirstPageNewsFromWTOfficial(region string, callback func(news []table.GameNew)) error {
-	baseUrl := fmt.Sprintf("https://warthunder.com/%s/news/", region)
-	c := colly.NewCollector(
-		colly.AllowedDomains("warthunder.com"),
-		colly.MaxDepth(1),
-		colly.IgnoreRobotsTxt(),
-	)
+	baseUrl := fmt.Sprintf("%s/%s/news/", WTOfficialBaseUrl, region)
+	c := newCollector(WTOfficialBaseUrl)
 	extensions.RandomUserAgent(c)
 
 	c.OnHTML("div[class=showcase__content-wrapper]", func(e *colly.HTMLElement) {
@@ -1,44 +1,498 @@
 package crawler
 
 import (
-	"fmt"
-	table2 "github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"context"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
+// newFixtureServer 使用testdata中保存的页面模拟战雷官网和thunderskill，并将爬虫指向该服务
+func newFixtureServer(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zh/community/userinfo/", func(w http.ResponseWriter, r *http.Request) {
+		nick := r.URL.Query().Get("nick")
+		if nick == "ServerError" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", "wt_profile_"+nick+".html"))
+		if err != nil {
+			// 官网对不存在的玩家同样返回200
+			body = readFixture(t, "wt_profile_not_found.html")
+		}
+		writeFixture(w, "text/html; charset=utf-8", body)
+	})
+	mux.HandleFunc("/en/stat/", func(w http.ResponseWriter, r *http.Request) {
+		nick := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/en/stat/"), "/export/json")
+		body, err := os.ReadFile(filepath.Join("testdata", "thunderskill_"+nick+".json"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeFixture(w, "application/json", body)
+	})
+	mux.HandleFunc("/zh/community/claninfo/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/zh/community/claninfo/")
+		body, err := os.ReadFile(filepath.Join("testdata", "wt_clan_"+strings.ReplaceAll(name, " ", "_")+".html"))
+		if err != nil {
+			body = readFixture(t, "wt_clan_not_found.html")
+		}
+		writeFixture(w, "text/html; charset=utf-8", body)
+	})
+	mux.HandleFunc("/en/news/", func(w http.ResponseWriter, r *http.Request) {
+		writeFixture(w, "text/html; charset=utf-8", readFixture(t, "wt_news_en.html"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	useBaseUrl(t, server.URL, server.URL)
+}
+
+// useBaseUrl 将爬虫指向测试用的地址，测试结束后还原
+func useBaseUrl(t *testing.T, wtBaseUrl string, tsBaseUrl string) {
+	prevWt, prevTs := WTOfficialBaseUrl, ThunderskillBaseUrl
+	WTOfficialBaseUrl, ThunderskillBaseUrl = wtBaseUrl, tsBaseUrl
+	t.Cleanup(func() {
+		WTOfficialBaseUrl, ThunderskillBaseUrl = prevWt, prevTs
+	})
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func writeFixture(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(body)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func TestGetProfileFromWTOfficial(t *testing.T) {
-	if err := GetProfileFromWTOfficial("OnTheRocks", func(status int, user *table2.GameUser) {
-		if status != StatusFound {
-			t.Failed()
+	newFixtureServer(t)
+	tests := []struct {
+		nick   string
+		status int
+		want   *table.GameUser
+	}{
+		{
+			nick:   "OnTheRocks",
+			status: StatusFound,
+			want: &table.GameUser{
+				Nick:         "OnTheRocks",
+				Clan:         "Rock Band",
+				ClanUrl:      "https://warthunder.com/zh/community/claninfo/Rock%20Band",
+				Banned:       boolPtr(false),
+				RegisterDate: time.Date(2015, 5, 12, 0, 0, 0, 0, time.UTC),
+				Title:        "坦克杀手",
+				Level:        100,
+				StatAb: table.UserStat{
+					TotalMission:         1234,
+					WinRate:              0.56,
+					WinCount:             691,
+					DeadCount:            2005,
+					GameTime:             "5天 3小时 20分钟",
+					AviationDestroyCount: 3456,
+					GroundDestroyCount:   789,
+					FleetDestroyCount:    12,
+					SliverEagleEarned:    98765432,
+				},
+				StatRb: table.UserStat{
+					TotalMission:         2345,
+					WinRate:              0.48,
+					WinCount:             1126,
+					DeadCount:            3210,
+					GameTime:             "9天 14小时 2分钟",
+					AviationDestroyCount: 4567,
+					GroundDestroyCount:   1890,
+					FleetDestroyCount:    45,
+					SliverEagleEarned:    123456789,
+				},
+				StatSb: table.UserStat{
+					TotalMission:         321,
+					WinRate:              0.39,
+					WinCount:             125,
+					DeadCount:            402,
+					GameTime:             "1天 2小时 33分钟",
+					AviationDestroyCount: 210,
+					GroundDestroyCount:   0,
+					FleetDestroyCount:    0,
+					SliverEagleEarned:    5432100,
+				},
+				AviationRateAb: table.AviationRate{
+					GameCount:            1200,
+					FighterGameCount:     1311,
+					BomberGameCount:      1422,
+					AttackerGameCount:    1533,
+					GameTime:             "1天 4小时 28分钟",
+					FighterGameTime:      "1天 5小时 35分钟",
+					BomberGameTime:       "1天 6小时 42分钟",
+					AttackerGameTime:     "1天 7小时 49分钟",
+					TotalDestroyCount:    2088,
+					AviationDestroyCount: 2199,
+					GroundDestroyCount:   2310,
+					FleetDestroyCount:    2421,
+				},
+				AviationRateRb: table.AviationRate{
+					GameCount:            2407,
+					FighterGameCount:     2518,
+					BomberGameCount:      2629,
+					AttackerGameCount:    2740,
+					GameTime:             "2天 4小时 41分钟",
+					FighterGameTime:      "2天 5小时 48分钟",
+					BomberGameTime:       "2天 6小时 55分钟",
+					AttackerGameTime:     "2天 7小时 2分钟",
+					TotalDestroyCount:    3295,
+					AviationDestroyCount: 3406,
+					GroundDestroyCount:   3517,
+					FleetDestroyCount:    3628,
+				},
+				AviationRateSb: table.AviationRate{
+					GameCount:            3614,
+					FighterGameCount:     3725,
+					BomberGameCount:      3836,
+					AttackerGameCount:    3947,
+					GameTime:             "3天 4小时 54分钟",
+					FighterGameTime:      "3天 5小时 1分钟",
+					BomberGameTime:       "3天 6小时 8分钟",
+					AttackerGameTime:     "3天 7小时 15分钟",
+					TotalDestroyCount:    4502,
+					AviationDestroyCount: 4613,
+					GroundDestroyCount:   4724,
+					FleetDestroyCount:    4835,
+				},
+				GroundRateAb: table.GroundRate{
+					GameCount:              1500,
+					GroundVehicleGameCount: 1611,
+					TDGameCount:            1722,
+					HTGameCount:            1833,
+					SPAAGameCount:          1944,
+					GameTime:               "1天 5小时 35分钟",
+					GroundVehicleGameTime:  "1天 6小时 42分钟",
+					TDGameTime:             "1天 7小时 49分钟",
+					HTGameTime:             "1天 8小时 56分钟",
+					SPAAGameTime:           "1天 9小时 3分钟",
+					TotalDestroyCount:      2610,
+					AviationDestroyCount:   2721,
+					GroundDestroyCount:     2832,
+					FleetDestroyCount:      2943,
+				},
+				GroundRateRb: table.GroundRate{
+					GameCount:              3007,
+					GroundVehicleGameCount: 3118,
+					TDGameCount:            3229,
+					HTGameCount:            3340,
+					SPAAGameCount:          3451,
+					GameTime:               "2天 5小时 48分钟",
+					GroundVehicleGameTime:  "2天 6小时 55分钟",
+					TDGameTime:             "2天 7小时 2分钟",
+					HTGameTime:             "2天 8小时 9分钟",
+					SPAAGameTime:           "2天 9小时 16分钟",
+					TotalDestroyCount:      4117,
+					AviationDestroyCount:   4228,
+					GroundDestroyCount:     4339,
+					FleetDestroyCount:      4450,
+				},
+				GroundRateSb: table.GroundRate{
+					GameCount:              4514,
+					GroundVehicleGameCount: 4625,
+					TDGameCount:            4736,
+					HTGameCount:            4847,
+					SPAAGameCount:          4958,
+					GameTime:               "3天 5小时 1分钟",
+					GroundVehicleGameTime:  "3天 6小时 8分钟",
+					TDGameTime:             "3天 7小时 15分钟",
+					HTGameTime:             "3天 8小时 22分钟",
+					SPAAGameTime:           "3天 9小时 29分钟",
+					TotalDestroyCount:      5624,
+					AviationDestroyCount:   5735,
+					GroundDestroyCount:     5846,
+					FleetDestroyCount:      5957,
+				},
+				FleetRateAb: table.FleetRate{
+					GameCount:               310,
+					FleetGameCount:          421,
+					TorpedoBoatGameCount:    532,
+					GunboatGameCount:        643,
+					TorpedoGunboatGameCount: 754,
+					SubmarineHuntGameCount:  865,
+					DestroyerGameCount:      976,
+					NavyBargeGameCount:      1087,
+					GameTime:                "1天 8小时 56分钟",
+					FleetGameTime:           "1天 9小时 3分钟",
+					TorpedoBoatGameTime:     "1天 10小时 10分钟",
+					GunboatGameTime:         "1天 11小时 17分钟",
+					TorpedoGunboatGameTime:  "1天 12小时 24分钟",
+					SubmarineHuntGameTime:   "1天 13小时 31分钟",
+					DestroyerGameTime:       "1天 14小时 38分钟",
+					NavyBargeGameTime:       "1天 15小时 45分钟",
+					TotalDestroyCount:       2086,
+					AviationDestroyCount:    2197,
+					GroundDestroyCount:      2308,
+					FleetDestroyCount:       2419,
+				},
+				FleetRateRb: table.FleetRate{
+					GameCount:               627,
+					FleetGameCount:          738,
+					TorpedoBoatGameCount:    849,
+					GunboatGameCount:        960,
+					TorpedoGunboatGameCount: 1071,
+					SubmarineHuntGameCount:  1182,
+					DestroyerGameCount:      1293,
+					NavyBargeGameCount:      1404,
+					GameTime:                "2天 8小时 9分钟",
+					FleetGameTime:           "2天 9小时 16分钟",
+					TorpedoBoatGameTime:     "2天 10小时 23分钟",
+					GunboatGameTime:         "2天 11小时 30分钟",
+					TorpedoGunboatGameTime:  "2天 12小时 37分钟",
+					SubmarineHuntGameTime:   "2天 13小时 44分钟",
+					DestroyerGameTime:       "2天 14小时 51分钟",
+					NavyBargeGameTime:       "2天 15小时 58分钟",
+					TotalDestroyCount:       2403,
+					AviationDestroyCount:    2514,
+					GroundDestroyCount:      2625,
+					FleetDestroyCount:       2736,
+				},
+				FleetRateSb: table.FleetRate{
+					GameCount:               944,
+					FleetGameCount:          1055,
+					TorpedoBoatGameCount:    1166,
+					GunboatGameCount:        1277,
+					TorpedoGunboatGameCount: 1388,
+					SubmarineHuntGameCount:  1499,
+					DestroyerGameCount:      1610,
+					NavyBargeGameCount:      1721,
+					GameTime:                "3天 8小时 22分钟",
+					FleetGameTime:           "3天 9小时 29分钟",
+					TorpedoBoatGameTime:     "3天 10小时 36分钟",
+					GunboatGameTime:         "3天 11小时 43分钟",
+					TorpedoGunboatGameTime:  "3天 12小时 50分钟",
+					SubmarineHuntGameTime:   "3天 13小时 57分钟",
+					DestroyerGameTime:       "3天 14小时 4分钟",
+					NavyBargeGameTime:       "3天 15小时 11分钟",
+					TotalDestroyCount:       2720,
+					AviationDestroyCount:    2831,
+					GroundDestroyCount:      2942,
+					FleetDestroyCount:       3053,
+				},
+			},
+		},
+		{
+			nick:   "Cheater42",
+			status: StatusFound,
+			want: &table.GameUser{
+				Nick:         "Cheater42",
+				Banned:       boolPtr(true),
+				RegisterDate: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC),
+				Title:        "新兵",
+				Level:        7,
+			},
+		},
+		{nick: "NoSuchPlayer", status: StatusNotFound},
+		{nick: "ServerError", status: StatusQueryFailed},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var statuses []int
+			var got *table.GameUser
+			err := GetProfileFromWTOfficial(tt.nick, func(status int, user *table.GameUser) {
+				statuses = append(statuses, status)
+				got = user
+			})
+			if tt.status == StatusQueryFailed {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Contains(t, statuses, tt.status)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestGetProfileClanTagFromWTOfficial 玩家资料中的联队以标签显示时，联队链接仍然指向联队名称
+func TestGetProfileClanTagFromWTOfficial(t *testing.T) {
+	newFixtureServer(t)
+	var got *table.GameUser
+	err := GetProfileFromWTOfficial("Pebble", func(status int, user *table.GameUser) {
+		if status == StatusFound {
+			got = user
 		}
-		assert.Equal(t, "OnTheRocks", user.Nick)
-		assert.Equal(t, 100, user.Level)
-	}); err != nil {
-		t.Failed()
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "Pebble", got.Nick)
+		assert.Equal(t, "-RB-", got.Clan)
+		assert.Equal(t, "https://warthunder.com/zh/community/claninfo/Rock%20Band", got.ClanUrl)
+	}
+}
+
+func TestGetClanFromWTOfficial(t *testing.T) {
+	newFixtureServer(t)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name    string
+		status  int
+		clan    *table.GameClan
+		members []table.GameClanMember
+	}{
+		{
+			name:   "Rock Band",
+			status: StatusFound,
+			clan: &table.GameClan{
+				Tag:          "-RB-",
+				Name:         "Rock Band",
+				Url:          WTOfficialBaseUrl + "/zh/community/claninfo/Rock%20Band",
+				Description:  "周末历史联队战，欢迎有麦的车长加入",
+				RegisterDate: date(2019, 3, 12),
+				MemberCount:  5,
+			},
+			members: []table.GameClanMember{
+				{Tag: "-RB-", Nick: "OnTheRocks", Rating: 2450, Activity: 98, Role: "指挥官", JoinDate: date(2019, 3, 12)},
+				{Tag: "-RB-", Nick: "Cheater42", Rating: 0, Activity: 0, Role: "士兵", JoinDate: date(2022, 1, 3)},
+				{Tag: "-RB-", Nick: "Pebble", Rating: 1890, Activity: 87, Role: "副指挥官", JoinDate: date(2019, 3, 20)},
+				{Tag: "-RB-", Nick: "Gravel_Hog", Rating: 1234, Activity: 64, Role: "军官", JoinDate: date(2020, 7, 1)},
+				{Tag: "-RB-", Nick: "lonely@psn", Rating: 75, Activity: 12, Role: "新兵", JoinDate: date(2026, 9, 15)},
+			},
+		},
+		{name: "No Such Clan", status: StatusNotFound},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var got int
+			var clan *table.GameClan
+			var members []table.GameClanMember
+			err := GetClanFromWTOfficial(tt.name, func(status int, c *table.GameClan, m []table.GameClanMember) {
+				got, clan, members = status, c, m
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, got)
+			assert.Equal(t, tt.clan, clan)
+			assert.Equal(t, tt.members, members)
+		})
 	}
 }
 
 func TestGetProfileFromThunderskill(t *testing.T) {
-	if err := GetProfileFromThunderskill("OnTheRocks", func(status int, skill *ThunderSkillResp) {
-		if status != StatusFound {
-			t.Failed()
-		}
-		assert.Equal(t, 84.72, skill.Stats.R.Kpd)
-		assert.Equal(t, 88.57, skill.Stats.S.Kpd)
-		assert.Equal(t, 82.48, skill.Stats.A.Kpd)
-	}); err != nil {
-		t.Failed()
+	newFixtureServer(t)
+	tests := []struct {
+		nick   string
+		called bool
+		a      float64
+		r      float64
+		s      float64
+	}{
+		{nick: "OnTheRocks", called: true, a: 82.48, r: 84.72, s: 88.57},
+		{nick: "NoSuchPlayer", called: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var got *ThunderSkillResp
+			err := GetProfileFromThunderskill(tt.nick, func(status int, skill *ThunderSkillResp) {
+				assert.Equal(t, StatusFound, status)
+				got = skill
+			})
+			assert.Equal(t, tt.called, got != nil)
+			if !tt.called {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.nick, got.Stats.Nick)
+			assert.Equal(t, tt.a, got.Stats.A.Kpd)
+			assert.Equal(t, tt.r, got.Stats.R.Kpd)
+			assert.Equal(t, tt.s, got.Stats.S.Kpd)
+		})
 	}
 }
 
 func TestGetFirstPageNewsFromWTOfficial(t *testing.T) {
-	if err := GetFirstPageNewsFromWTOfficial("en", func(news []table2.GameNew) {
-		fmt.Println(len(news))
-		for _, i := range news {
-			fmt.Println(i)
-		}
-	}); err != nil {
-		t.Failed()
+	newFixtureServer(t)
+	var got []table.GameNew
+	err := GetFirstPageNewsFromWTOfficial("en", func(news []table.GameNew) {
+		got = news
+	})
+	assert.NoError(t, err)
+	// 列表按页面顺序倒序，最新的新闻在最后
+	want := []table.GameNew{
+		{
+			Link:      "https://warthunder.com/en/news/8695-event-autumn-operation-en",
+			PosterUrl: "https://static-news.warthunder.com/upload/image/8695_poster.jpg",
+			Title:     "Autumn Operation",
+			Comment:   "Complete tasks to earn unique rewards.",
+			DateStr:   "15 October 2026",
+		},
+		{
+			Link:      "https://warthunder.com/en/news/8702-development-new-ground-vehicles-en",
+			PosterUrl: "https://static-news.warthunder.com/upload/image/8702_poster.jpg",
+			Title:     "New Ground Vehicles",
+			Comment:   "A look at the upcoming tanks of the next major update.",
+			DateStr:   "18 October 2026",
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestDefaultRegistryFetch(t *testing.T) {
+	newFixtureServer(t)
+	tests := []struct {
+		nick   string
+		found  bool
+		level  int
+		tsRate float64
+	}{
+		{nick: "OnTheRocks", found: true, level: 100, tsRate: 82.48},
+		{nick: "Cheater42", found: true, level: 7},
+		{nick: "NoSuchPlayer", found: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			result, err := DefaultRegistry().Fetch(context.Background(), tt.nick)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.found, result.Found)
+			if !tt.found {
+				return
+			}
+			assert.Equal(t, tt.nick, result.User.Nick)
+			assert.Equal(t, tt.level, result.User.Level)
+			assert.Equal(t, tt.tsRate, result.User.TsABRate)
+		})
+	}
+}
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		str     string
+		common  int
+		se      int64
+		winRate float64
+	}{
+		{str: "1,234", common: 1234, se: 1234},
+		{str: "98,765,432", common: 98765432, se: 98765432},
+		{str: "56%", winRate: 0.56},
+		{str: "0", common: 0, se: 0},
+		{str: "", common: 0, se: 0},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.common, parseCommonNumber(tt.str))
+			assert.Equal(t, tt.se, parseSENumber(tt.str))
+			assert.Equal(t, tt.winRate, parseWinRate(tt.str))
+		})
 	}
 }
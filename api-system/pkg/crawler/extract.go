@@ -167,7 +167,7 @@ func extractFleetUserRate(keys []string, s *goquery.Selection) table2.FleetRate
 		TorpedoBoatGameCount:    parseCommonNumber(mp["参战次数(鱼雷艇)"]),
 		GunboatGameCount:        parseCommonNumber(mp["参战次数(炮艇)"]),
 		TorpedoGunboatGameCount: parseCommonNumber(mp["参战次数(鱼雷炮艇)"]),
-		SubmarineHuntGameCount:  parseCommonNumber(mp["参战次数(猎潜艇))"]),
+		SubmarineHuntGameCount:  parseCommonNumber(mp["参战次数(猎潜艇)"]),
 		DestroyerGameCount:      parseCommonNumber(mp["参战次数(驱逐舰)"]),
 		NavyBargeGameCount:      parseCommonNumber(mp["参战次数(海军驳渡船)"]),
 		GameTime:                mp["游戏时长(海战)"],
@@ -203,6 +203,62 @@ func parseSENumber(str string) int64 {
 	return atoi
 }
 
+// clanMemberColumns 联队成员表格每一行的列数：序号、昵称、个人评分、活跃度、职位、加入日期
+const clanMemberColumns = 6
+
+// ExtractGaijinClan 解析联队页面，页面中没有联队信息时返回的联队标签为空
+func ExtractGaijinClan(e *colly.HTMLElement) (table2.GameClan, []table2.GameClanMember) {
+	var clan table2.GameClan
+	dom := e.DOM
+	clan.Tag, clan.Name = extractClanTitle(dom.Find("div[class~='squadrons-info__title']").Text())
+	clan.Description = strings.TrimSpace(dom.Find("div[class~='squadrons-info__description']").Text())
+	dom.Find("div[class~='squadrons-info__meta-item']").Each(func(i int, item *goquery.Selection) {
+		text := strings.TrimSpace(item.Text())
+		if strings.HasPrefix(text, "创建日期") {
+			clan.RegisterDate = extractClanDate(text)
+		}
+	})
+
+	var members []table2.GameClanMember
+	cells := dom.Find("div[class~='squadrons-members__table']>div[class~='squadrons-members__grid-item']")
+	// 第一行为表头
+	for i := clanMemberColumns; i+clanMemberColumns <= cells.Length(); i += clanMemberColumns {
+		nick := strings.TrimSpace(cells.Eq(i + 1).Text())
+		if nick == "" {
+			continue
+		}
+		members = append(members, table2.GameClanMember{
+			Tag:      clan.Tag,
+			Nick:     nick,
+			Rating:   parseCommonNumber(strings.TrimSpace(cells.Eq(i + 2).Text())),
+			Activity: parseCommonNumber(strings.TrimSpace(cells.Eq(i + 3).Text())),
+			Role:     strings.TrimSpace(cells.Eq(i + 4).Text()),
+			JoinDate: extractClanDate(cells.Eq(i + 5).Text()),
+		})
+	}
+	clan.MemberCount = len(members)
+	return clan, members
+}
+
+// extractClanTitle 联队标题的格式为“标签 全称”
+func extractClanTitle(str string) (string, string) {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	return fields[0], strings.Join(fields[1:], " ")
+}
+
+// extractClanDate 从文本中取出最后一个 dd.mm.yyyy 格式的日期
+func extractClanDate(str string) time.Time {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return time.Time{}
+	}
+	parse, _ := time.Parse("02.01.2006", fields[len(fields)-1])
+	return parse
+}
+
 func ExtractGaijinNews(e *colly.HTMLElement) []table2.GameNew {
 	var lst []table2.GameNew
 	dom := e.DOM
@@ -0,0 +1,327 @@
+package crawler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"github.com/gocolly/colly/v2"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Options 爬虫的网络配置
+type Options struct {
+	// Proxies 轮流使用的代理地址，为空时直连
+	Proxies []string
+	// CookieFile cookie持久化的文件，为空时cookie只保存在内存中
+	CookieFile string
+	// MinInterval 对同一个站点两次请求之间的最小间隔
+	MinInterval time.Duration
+	// BaseBackoff 被拦截或限流后第一次退避的时间，之后每次翻倍，为0时不退避
+	BaseBackoff time.Duration
+	// MaxBackoff 退避时间的上限
+	MaxBackoff time.Duration
+}
+
+// network 所有爬虫共享的网络状态，保证cookie和限流在多次爬取之间生效
+type network struct {
+	jar       *persistentJar
+	transport *throttledTransport
+}
+
+var (
+	networkMu      sync.RWMutex
+	currentNetwork = mustNewNetwork(Options{})
+)
+
+// Setup 按配置初始化爬虫的代理、cookie和限流
+func Setup(opts Options) error {
+	n, err := newNetwork(opts)
+	if err != nil {
+		return err
+	}
+	networkMu.Lock()
+	currentNetwork = n
+	networkMu.Unlock()
+	return nil
+}
+
+func getNetwork() *network {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	return currentNetwork
+}
+
+func mustNewNetwork(opts Options) *network {
+	n, err := newNetwork(opts)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func newNetwork(opts Options) (*network, error) {
+	jar, err := newPersistentJar(opts.CookieFile)
+	if err != nil {
+		return nil, err
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if len(opts.Proxies) > 0 {
+		proxyFunc, err := roundRobinProxy(opts.Proxies)
+		if err != nil {
+			return nil, err
+		}
+		base.Proxy = proxyFunc
+	}
+	return &network{
+		jar: jar,
+		transport: &throttledTransport{
+			base:    base,
+			limiter: newHostLimiter(opts.MinInterval, opts.BaseBackoff, opts.MaxBackoff),
+		},
+	}, nil
+}
+
+// roundRobinProxy 每次请求轮流使用一个代理。colly自带的实现会修改请求本身，和http.Transport存在数据竞争
+func roundRobinProxy(proxies []string) (func(*http.Request) (*url.URL, error), error) {
+	urls := make([]*url.URL, len(proxies))
+	for i, p := range proxies {
+		u, err := url.Parse(p)
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = u
+	}
+	var index uint32
+	return func(*http.Request) (*url.URL, error) {
+		i := atomic.AddUint32(&index, 1) - 1
+		return urls[i%uint32(len(urls))], nil
+	}, nil
+}
+
+func (n *network) apply(c *colly.Collector) {
+	c.SetCookieJar(n.jar)
+	c.WithTransport(n.transport)
+}
+
+// hostLimiter 按站点限制请求频率，被拦截或限流时指数退避
+type hostLimiter struct {
+	mu          sync.Mutex
+	interval    time.Duration
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	hosts       map[string]*hostState
+}
+
+type hostState struct {
+	// next 下一次允许请求的时间
+	next    time.Time
+	backoff time.Duration
+}
+
+func newHostLimiter(interval time.Duration, baseBackoff time.Duration, maxBackoff time.Duration) *hostLimiter {
+	if maxBackoff < baseBackoff {
+		maxBackoff = baseBackoff
+	}
+	return &hostLimiter{
+		interval:    interval,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+		hosts:       make(map[string]*hostState),
+	}
+}
+
+func (l *hostLimiter) state(host string) *hostState {
+	s, ok := l.hosts[host]
+	if !ok {
+		s = &hostState{}
+		l.hosts[host] = s
+	}
+	return s
+}
+
+// reserve 预约一次请求，返回需要等待的时间
+func (l *hostLimiter) reserve(host string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := l.state(host)
+	start := now
+	if s.next.After(now) {
+		start = s.next
+	}
+	s.next = start.Add(l.interval)
+	return start.Sub(now)
+}
+
+// report 记录请求的结果，被拦截时延后下一次请求，成功时清除退避。retryAfter为服务端要求的等待时间
+func (l *hostLimiter) report(host string, blocked bool, retryAfter time.Duration, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := l.state(host)
+	if !blocked {
+		s.backoff = 0
+		return
+	}
+	if l.baseBackoff <= 0 {
+		return
+	}
+	if s.backoff == 0 {
+		s.backoff = l.baseBackoff
+	} else {
+		s.backoff *= 2
+	}
+	if s.backoff > l.maxBackoff {
+		s.backoff = l.maxBackoff
+	}
+	wait := s.backoff
+	if retryAfter > wait {
+		wait = retryAfter
+	}
+	if next := now.Add(wait); next.After(s.next) {
+		s.next = next
+	}
+}
+
+// throttledTransport 在发送请求前按站点等待，并根据响应调整退避
+type throttledTransport struct {
+	base    http.RoundTripper
+	limiter *hostLimiter
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if wait := t.limiter.reserve(host, time.Now()); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	blocked := isBlockedResponse(resp.StatusCode, resp.Header)
+	if blocked {
+		logging.L().Warn("crawler blocked by remote site",
+			logging.Any("host", host),
+			logging.Any("statusCode", resp.StatusCode))
+	}
+	t.limiter.report(host, blocked, parseRetryAfter(resp.Header.Get("Retry-After")), time.Now())
+	return resp, nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// persistentJar 可以将cookie保存到文件中的cookie jar，重启后仍然可以使用cloudflare下发的cookie
+type persistentJar struct {
+	*cookiejar.Jar
+	// mu 保证cookie记录和文件写入的顺序
+	mu   sync.Mutex
+	file string
+	// cookies 按站点记录的cookie，用于写入文件
+	cookies map[string]map[string]*http.Cookie
+}
+
+func newPersistentJar(file string) (*persistentJar, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	j := &persistentJar{Jar: jar, file: file, cookies: make(map[string]map[string]*http.Cookie)}
+	if file == "" {
+		return j, nil
+	}
+	bytes, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return j, nil
+		}
+		return nil, err
+	}
+	var saved map[string][]*http.Cookie
+	if err := json.Unmarshal(bytes, &saved); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for site, cookies := range saved {
+		u, err := url.Parse(site)
+		if err != nil {
+			continue
+		}
+		var valid []*http.Cookie
+		for _, cookie := range cookies {
+			if cookie.Expires.IsZero() || cookie.Expires.After(now) {
+				valid = append(valid, cookie)
+			}
+		}
+		j.record(u, valid)
+		j.Jar.SetCookies(u, valid)
+	}
+	return j, nil
+}
+
+func (j *persistentJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.Jar.SetCookies(u, cookies)
+	if j.file == "" {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.record(u, cookies)
+	if err := j.save(); err != nil {
+		logging.L().Warn("save crawler cookies failed", logging.Error(err))
+	}
+}
+
+func (j *persistentJar) record(u *url.URL, cookies []*http.Cookie) {
+	site := u.Scheme + "://" + u.Host + "/"
+	if j.cookies[site] == nil {
+		j.cookies[site] = make(map[string]*http.Cookie)
+	}
+	for _, cookie := range cookies {
+		c := *cookie
+		// 文件中只保存过期时间
+		if c.MaxAge > 0 {
+			c.Expires = time.Now().Add(time.Duration(c.MaxAge) * time.Second)
+			c.MaxAge = 0
+		}
+		if c.MaxAge < 0 {
+			delete(j.cookies[site], c.Name)
+			continue
+		}
+		j.cookies[site][c.Name] = &c
+	}
+}
+
+func (j *persistentJar) save() error {
+	saved := make(map[string][]*http.Cookie, len(j.cookies))
+	for site, cookies := range j.cookies {
+		for _, cookie := range cookies {
+			saved[site] = append(saved[site], cookie)
+		}
+	}
+	bytes, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(j.file, bytes, 0600)
+}
@@ -0,0 +1,279 @@
+package crawler
+
+import (
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// useNetwork 使用指定的网络配置，测试结束后还原
+func useNetwork(t *testing.T, opts Options) {
+	prev := getNetwork()
+	if err := Setup(opts); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		networkMu.Lock()
+		currentNetwork = prev
+		networkMu.Unlock()
+	})
+}
+
+// writeChallenge 模拟cloudflare返回的人机验证页面
+func writeChallenge(t *testing.T, w http.ResponseWriter, statusCode int) {
+	w.Header().Set("Server", "cloudflare")
+	w.Header().Set("Cf-Mitigated", "challenge")
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(readFixture(t, "wt_challenge.html"))
+}
+
+func fetchProfileStatus(nick string) (int, error) {
+	var got int
+	err := GetProfileFromWTOfficial(nick, func(status int, user *table.GameUser) {
+		got = status
+	})
+	return got, err
+}
+
+func TestGetProfileChallenged(t *testing.T) {
+	useNetwork(t, Options{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("nick") {
+		case "Forbidden":
+			writeChallenge(t, w, http.StatusForbidden)
+		case "Unavailable":
+			writeChallenge(t, w, http.StatusServiceUnavailable)
+		case "Ok":
+			// 部分验证页面的状态码为200且没有Cf-Mitigated响应头
+			writeFixture(w, "text/html; charset=UTF-8", readFixture(t, "wt_challenge.html"))
+		case "RateLimited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			writeFixture(w, "text/html; charset=utf-8", readFixture(t, "wt_profile_with_cf_script.html"))
+		}
+	}))
+	defer server.Close()
+	useBaseUrl(t, server.URL, server.URL)
+
+	tests := []struct {
+		nick   string
+		status int
+		err    error
+	}{
+		{nick: "Forbidden", status: StatusChallenged, err: ErrChallenged},
+		{nick: "Unavailable", status: StatusChallenged, err: ErrChallenged},
+		{nick: "Ok", status: StatusChallenged, err: ErrChallenged},
+		{nick: "RateLimited", status: StatusQueryFailed},
+		{nick: "OnTheRocks", status: StatusFound},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			status, err := fetchProfileStatus(tt.nick)
+			assert.Equal(t, tt.status, status)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else if tt.status == StatusFound {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCookiePersistence(t *testing.T) {
+	var mu sync.Mutex
+	challenges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("cf_clearance"); err == nil && cookie.Value == "passed" {
+			writeFixture(w, "text/html; charset=utf-8", readFixture(t, "wt_profile_OnTheRocks.html"))
+			return
+		}
+		mu.Lock()
+		challenges++
+		mu.Unlock()
+		http.SetCookie(w, &http.Cookie{Name: "cf_clearance", Value: "passed", Path: "/", MaxAge: 3600})
+		writeChallenge(t, w, http.StatusForbidden)
+	}))
+	defer server.Close()
+	useBaseUrl(t, server.URL, server.URL)
+	cookieFile := filepath.Join(t.TempDir(), "cookies.json")
+
+	useNetwork(t, Options{CookieFile: cookieFile})
+	status, _ := fetchProfileStatus("OnTheRocks")
+	assert.Equal(t, StatusChallenged, status)
+	status, _ = fetchProfileStatus("OnTheRocks")
+	assert.Equal(t, StatusFound, status)
+
+	// 重新初始化后从文件中加载cookie，不会再次被拦截
+	useNetwork(t, Options{CookieFile: cookieFile})
+	status, _ = fetchProfileStatus("OnTheRocks")
+	assert.Equal(t, StatusFound, status)
+	assert.Equal(t, 1, challenges)
+
+	// 不持久化时重新初始化会丢失cookie
+	useNetwork(t, Options{})
+	status, _ = fetchProfileStatus("OnTheRocks")
+	assert.Equal(t, StatusChallenged, status)
+	assert.Equal(t, 2, challenges)
+}
+
+func TestProxyRotation(t *testing.T) {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	newProxy := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			hits[name]++
+			mu.Unlock()
+			// 代理收到的是完整的目标地址
+			assert.Equal(t, "warthunder.test", r.URL.Host)
+			writeFixture(w, "text/html; charset=utf-8", readFixture(t, "wt_profile_OnTheRocks.html"))
+		}))
+	}
+	proxyA, proxyB := newProxy("a"), newProxy("b")
+	defer proxyA.Close()
+	defer proxyB.Close()
+	useBaseUrl(t, "http://warthunder.test", "http://thunderskill.test")
+	useNetwork(t, Options{Proxies: []string{proxyA.URL, proxyB.URL}})
+
+	for i := 0; i < 4; i++ {
+		status, err := fetchProfileStatus("OnTheRocks")
+		assert.NoError(t, err)
+		assert.Equal(t, StatusFound, status)
+	}
+	assert.Equal(t, map[string]int{"a": 2, "b": 2}, hits)
+}
+
+func TestThrottledTransport(t *testing.T) {
+	tests := []struct {
+		opts Options
+		// blocked 前几次请求返回验证页面
+		blocked int
+		minGap  time.Duration
+	}{
+		{opts: Options{}, blocked: 0, minGap: 0},
+		{opts: Options{MinInterval: 50 * time.Millisecond}, blocked: 0, minGap: 50 * time.Millisecond},
+		{opts: Options{BaseBackoff: 80 * time.Millisecond, MaxBackoff: time.Second}, blocked: 1, minGap: 80 * time.Millisecond},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var mu sync.Mutex
+			var received []time.Time
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				received = append(received, time.Now())
+				count := len(received)
+				mu.Unlock()
+				if count <= tt.blocked {
+					writeChallenge(t, w, http.StatusServiceUnavailable)
+					return
+				}
+				writeFixture(w, "text/html; charset=utf-8", readFixture(t, "wt_profile_OnTheRocks.html"))
+			}))
+			defer server.Close()
+			useBaseUrl(t, server.URL, server.URL)
+			useNetwork(t, tt.opts)
+
+			for j := 0; j < 2; j++ {
+				_, _ = fetchProfileStatus("OnTheRocks")
+			}
+			assert.Len(t, received, 2)
+			// 间隔从发出请求时开始计算，服务端收到请求的时间会有少许误差
+			assert.GreaterOrEqual(t, received[1].Sub(received[0]), tt.minGap*9/10)
+		})
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	start := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	type step struct {
+		// blocked 为nil时预约一次请求，否则记录请求结果
+		blocked    *bool
+		retryAfter time.Duration
+		at         time.Duration
+		wait       time.Duration
+	}
+	yes, no := true, false
+	tests := []struct {
+		limiter *hostLimiter
+		steps   []step
+	}{
+		{
+			limiter: newHostLimiter(time.Second, 0, 0),
+			steps: []step{
+				{at: 0, wait: 0},
+				{at: 0, wait: time.Second},
+				{at: 500 * time.Millisecond, wait: 1500 * time.Millisecond},
+				{at: 5 * time.Second, wait: 0},
+			},
+		},
+		{
+			limiter: newHostLimiter(0, 10*time.Second, 30*time.Second),
+			steps: []step{
+				{at: 0, wait: 0},
+				{blocked: &yes, at: 0},
+				{at: 0, wait: 10 * time.Second},
+				{blocked: &yes, at: 10 * time.Second},
+				{at: 10 * time.Second, wait: 20 * time.Second},
+				{blocked: &yes, at: 30 * time.Second},
+				{at: 30 * time.Second, wait: 30 * time.Second},
+				{blocked: &yes, at: 60 * time.Second},
+				{at: 60 * time.Second, wait: 30 * time.Second},
+				{blocked: &no, at: 90 * time.Second},
+				{blocked: &yes, at: 90 * time.Second},
+				{at: 90 * time.Second, wait: 10 * time.Second},
+			},
+		},
+		{
+			limiter: newHostLimiter(0, time.Second, time.Minute),
+			steps: []step{
+				{blocked: &yes, retryAfter: 2 * time.Minute, at: 0},
+				{at: 0, wait: 2 * time.Minute},
+			},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			for _, s := range tt.steps {
+				now := start.Add(s.at)
+				if s.blocked != nil {
+					tt.limiter.report("warthunder.com", *s.blocked, s.retryAfter, now)
+					continue
+				}
+				assert.Equal(t, s.wait, tt.limiter.reserve("warthunder.com", now))
+			}
+			// 不同站点互不影响
+			assert.Equal(t, time.Duration(0), tt.limiter.reserve("thunderskill.com", start))
+		})
+	}
+}
+
+func TestIsBlockedResponse(t *testing.T) {
+	cloudflare := http.Header{"Server": []string{"cloudflare"}}
+	tests := []struct {
+		statusCode int
+		header     http.Header
+		want       bool
+	}{
+		{statusCode: http.StatusOK, header: http.Header{}, want: false},
+		{statusCode: http.StatusTooManyRequests, header: http.Header{}, want: true},
+		{statusCode: http.StatusForbidden, header: cloudflare, want: true},
+		{statusCode: http.StatusServiceUnavailable, header: cloudflare, want: true},
+		{statusCode: http.StatusServiceUnavailable, header: http.Header{}, want: false},
+		{statusCode: http.StatusOK, header: http.Header{"Cf-Mitigated": []string{"challenge"}}, want: true},
+		{statusCode: http.StatusNotFound, header: cloudflare, want: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, isBlockedResponse(tt.statusCode, tt.header))
+		})
+	}
+}
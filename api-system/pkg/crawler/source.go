@@ -0,0 +1,155 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProfileSource 玩家数据的来源，新增数据来源时实现该接口并注册到Registry中即可
+type ProfileSource interface {
+	// Name 来源的名称，用于记录每个来源的数据更新时间
+	Name() string
+	// Priority 合并时优先级高的来源覆盖优先级低的来源，优先级最高的来源决定玩家是否存在
+	Priority() int
+	Fetch(ctx context.Context, nick string) (*ProfileResult, error)
+}
+
+// ProfileResult 单个来源获取到的部分玩家数据
+type ProfileResult struct {
+	Found bool
+	// Merge 将本来源的数据合并到玩家数据中，只需要设置本来源负责的字段
+	Merge func(user *table.GameUser)
+}
+
+// SourceStatus 单个来源本次获取的结果
+type SourceStatus struct {
+	Name      string
+	Found     bool
+	FetchedAt time.Time
+	Err       error
+}
+
+// FetchResult 合并所有来源后的玩家数据
+type FetchResult struct {
+	Found   bool
+	User    table.GameUser
+	Sources []SourceStatus
+}
+
+var (
+	ErrNoProfileSource     = errors.New("no profile source registered")
+	ErrProfileSourceFailed = errors.New("profile source query failed")
+)
+
+type Registry struct {
+	mu      sync.RWMutex
+	sources []ProfileSource
+}
+
+func NewRegistry(sources ...ProfileSource) *Registry {
+	r := &Registry{}
+	for _, source := range sources {
+		r.Register(source)
+	}
+	return r
+}
+
+// Register 注册一个数据来源，来源按优先级从高到低排列
+func (r *Registry) Register(source ProfileSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, source)
+	sort.SliceStable(r.sources, func(i, j int) bool {
+		return r.sources[i].Priority() > r.sources[j].Priority()
+	})
+}
+
+func (r *Registry) Sources() []ProfileSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]ProfileSource(nil), r.sources...)
+}
+
+// Fetch 先从优先级最高的来源获取玩家数据，玩家存在时再并发从其他来源获取，并按优先级从低到高合并。
+// 优先级最高的来源获取失败时返回错误，其他来源的失败只记录在Sources中
+func (r *Registry) Fetch(ctx context.Context, nick string) (*FetchResult, error) {
+	sources := r.Sources()
+	if len(sources) == 0 {
+		return nil, ErrNoProfileSource
+	}
+	results := make([]*ProfileResult, len(sources))
+	statuses := make([]SourceStatus, len(sources))
+	fetch := func(i int) {
+		result, err := sources[i].Fetch(ctx, nick)
+		statuses[i] = SourceStatus{Name: sources[i].Name(), FetchedAt: time.Now(), Err: err}
+		if err != nil {
+			logging.L().Warn("fetch profile from source failed",
+				logging.Error(err),
+				logging.Any("source", sources[i].Name()),
+				logging.Any("nick", nick))
+			return
+		}
+		results[i] = result
+		statuses[i].Found = result.Found
+	}
+
+	fetch(0)
+	if statuses[0].Err != nil {
+		return nil, statuses[0].Err
+	}
+	ret := &FetchResult{Found: results[0].Found}
+	if !ret.Found {
+		ret.Sources = statuses[:1]
+		return ret, nil
+	}
+	var wg sync.WaitGroup
+	for i := 1; i < len(sources); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fetch(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := len(sources) - 1; i >= 0; i-- {
+		if results[i] != nil && results[i].Found && results[i].Merge != nil {
+			results[i].Merge(&ret.User)
+		}
+	}
+	// live, psn等用户的昵称在html中会被cf认为是邮箱而隐藏，这里需要覆盖爬取来的数据
+	ret.User.Nick = nick
+	ret.Sources = statuses
+	return ret, nil
+}
+
+// fetchWithContext 在ctx结束时不再等待fetch的结果
+func fetchWithContext(ctx context.Context, fetch func() (*ProfileResult, error)) (*ProfileResult, error) {
+	type fetched struct {
+		result *ProfileResult
+		err    error
+	}
+	ch := make(chan fetched, 1)
+	go func() {
+		result, err := fetch()
+		ch <- fetched{result: result, err: err}
+	}()
+	select {
+	case f := <-ch:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+var defaultRegistry = NewRegistry(WTOfficialSource{}, ThunderskillSource{})
+
+// DefaultRegistry 默认的数据来源：战雷官网和thunderskill
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
@@ -0,0 +1,110 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+type fakeSource struct {
+	name     string
+	priority int
+	found    bool
+	err      error
+	level    int
+	tsRate   float64
+}
+
+func (s fakeSource) Name() string {
+	return s.name
+}
+
+func (s fakeSource) Priority() int {
+	return s.priority
+}
+
+func (s fakeSource) Fetch(ctx context.Context, nick string) (*ProfileResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ProfileResult{Found: s.found, Merge: func(user *table.GameUser) {
+		if s.level != 0 {
+			user.Level = s.level
+		}
+		if s.tsRate != 0 {
+			user.TsABRate = s.tsRate
+		}
+	}}, nil
+}
+
+func TestRegistryFetch(t *testing.T) {
+	failed := errors.New("failed")
+	tests := []struct {
+		sources  []ProfileSource
+		err      bool
+		found    bool
+		level    int
+		tsRate   float64
+		statuses int
+	}{
+		{
+			sources: []ProfileSource{
+				fakeSource{name: "ts", priority: 10, found: true, tsRate: 80},
+				fakeSource{name: "wt", priority: 100, found: true, level: 100},
+			},
+			found: true, level: 100, tsRate: 80, statuses: 2,
+		},
+		{
+			sources: []ProfileSource{
+				fakeSource{name: "wt", priority: 100, found: true, level: 100},
+				fakeSource{name: "other", priority: 50, found: true, level: 50},
+			},
+			found: true, level: 100, statuses: 2,
+		},
+		{
+			sources: []ProfileSource{
+				fakeSource{name: "wt", priority: 100, found: true, level: 100},
+				fakeSource{name: "ts", priority: 10, err: failed},
+			},
+			found: true, level: 100, statuses: 2,
+		},
+		{
+			sources: []ProfileSource{
+				fakeSource{name: "wt", priority: 100, found: false},
+				fakeSource{name: "ts", priority: 10, found: true, tsRate: 80},
+			},
+			found: false, statuses: 1,
+		},
+		{
+			sources: []ProfileSource{
+				fakeSource{name: "wt", priority: 100, err: failed},
+				fakeSource{name: "ts", priority: 10, found: true, tsRate: 80},
+			},
+			err: true,
+		},
+		{
+			sources: nil,
+			err:     true,
+		},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			result, err := NewRegistry(test.sources...).Fetch(context.Background(), "nick")
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.found, result.Found)
+			assert.Len(t, result.Sources, test.statuses)
+			if test.found {
+				assert.Equal(t, "nick", result.User.Nick)
+				assert.Equal(t, test.level, result.User.Level)
+				assert.Equal(t, test.tsRate, result.User.TsABRate)
+			}
+		})
+	}
+}
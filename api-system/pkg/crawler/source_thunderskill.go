@@ -0,0 +1,38 @@
+package crawler
+
+import (
+	"context"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+)
+
+// ThunderskillSource thunderskill的效率值
+type ThunderskillSource struct{}
+
+func (ThunderskillSource) Name() string {
+	return "thunderskill"
+}
+
+func (ThunderskillSource) Priority() int {
+	return 10
+}
+
+func (ThunderskillSource) Fetch(ctx context.Context, nick string) (*ProfileResult, error) {
+	return fetchWithContext(ctx, func() (*ProfileResult, error) {
+		var result *ProfileResult
+		err := GetProfileFromThunderskill(nick, func(status int, skill *ThunderSkillResp) {
+			stats := skill.Stats
+			result = &ProfileResult{Found: true, Merge: func(dst *table.GameUser) {
+				dst.TsABRate = stats.A.Kpd
+				dst.TsRBRate = stats.R.Kpd
+				dst.TsSBRate = stats.S.Kpd
+			}}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, ErrProfileSourceFailed
+		}
+		return result, nil
+	})
+}
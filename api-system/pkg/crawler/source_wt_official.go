@@ -0,0 +1,67 @@
+package crawler
+
+import (
+	"context"
+	"github.com/axiangcoding/antonstar-bot/internal/data/table"
+)
+
+// WTOfficialSource 战雷官网的玩家资料，是玩家是否存在的依据
+type WTOfficialSource struct{}
+
+func (WTOfficialSource) Name() string {
+	return "wt_official"
+}
+
+func (WTOfficialSource) Priority() int {
+	return 100
+}
+
+func (WTOfficialSource) Fetch(ctx context.Context, nick string) (*ProfileResult, error) {
+	return fetchWithContext(ctx, func() (*ProfileResult, error) {
+		var result *ProfileResult
+		failed := false
+		err := GetProfileFromWTOfficial(nick, func(status int, user *table.GameUser) {
+			switch status {
+			case StatusFound:
+				data := *user
+				result = &ProfileResult{Found: true, Merge: func(dst *table.GameUser) {
+					mergeGaijinProfile(dst, &data)
+				}}
+			case StatusNotFound:
+				result = &ProfileResult{Found: false}
+			case StatusChallenged:
+				// 被拦截时GetProfileFromWTOfficial会返回ErrChallenged
+			default:
+				failed = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if failed || result == nil {
+			return nil, ErrProfileSourceFailed
+		}
+		return result, nil
+	})
+}
+
+func mergeGaijinProfile(dst *table.GameUser, src *table.GameUser) {
+	dst.Clan = src.Clan
+	dst.ClanUrl = src.ClanUrl
+	dst.Banned = src.Banned
+	dst.RegisterDate = src.RegisterDate
+	dst.Title = src.Title
+	dst.Level = src.Level
+	dst.StatAb = src.StatAb
+	dst.StatRb = src.StatRb
+	dst.StatSb = src.StatSb
+	dst.GroundRateAb = src.GroundRateAb
+	dst.GroundRateRb = src.GroundRateRb
+	dst.GroundRateSb = src.GroundRateSb
+	dst.AviationRateAb = src.AviationRateAb
+	dst.AviationRateRb = src.AviationRateRb
+	dst.AviationRateSb = src.AviationRateSb
+	dst.FleetRateAb = src.FleetRateAb
+	dst.FleetRateRb = src.FleetRateRb
+	dst.FleetRateSb = src.FleetRateSb
+}
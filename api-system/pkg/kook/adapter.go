@@ -0,0 +1,164 @@
+package kook
+
+import (
+	"errors"
+	"fmt"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/axiangcoding/antonstar-bot/pkg/logging"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidId = errors.New("kook id not valid")
+
+// toBotId kook的用户和频道id与qq号处于同一个数值范围，转换为负数作为平台无关的id，
+// 避免与qq的用户和群共用配置、权限和绑定
+func toBotId(id string) (int64, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, ErrInvalidId
+	}
+	return -n, nil
+}
+
+// fromBotId 将平台无关的id还原为kook的id
+func fromBotId(id int64) string {
+	return strconv.FormatInt(-id, 10)
+}
+
+// ParseTarget 解析命令中指定的kook用户或频道，返回平台无关的id。
+// 支持 (met)用户id(met) 格式的@、kook原始的id，以及已经转换为负数的id
+func ParseTarget(arg string) (int64, bool) {
+	if strings.HasPrefix(arg, "(met)") && strings.HasSuffix(arg, "(met)") && len(arg) > len("(met)(met)") {
+		id, err := toBotId(arg[len("(met)") : len(arg)-len("(met)")])
+		return id, err == nil
+	}
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || n == 0 {
+		return 0, false
+	}
+	if n < 0 {
+		return n, true
+	}
+	return -n, true
+}
+
+// guildCacheDuration 服务器的角色和权限变化不频繁，缓存一段时间避免每条消息都请求接口
+const guildCacheDuration = time.Minute * 10
+
+type cachedGuild struct {
+	guild    *Guild
+	expireAt time.Time
+}
+
+// Adapter kook 的机器人平台适配器
+type Adapter struct {
+	client *Client
+	mu     sync.Mutex
+	guilds map[string]cachedGuild
+}
+
+func NewAdapter(client *Client) *Adapter {
+	return &Adapter{client: client, guilds: make(map[string]cachedGuild)}
+}
+
+// viewGuild 获取服务器详情，优先使用缓存
+func (a *Adapter) viewGuild(guildId string) (*Guild, error) {
+	a.mu.Lock()
+	cached, ok := a.guilds[guildId]
+	a.mu.Unlock()
+	if ok && time.Now().Before(cached.expireAt) {
+		return cached.guild, nil
+	}
+	guild, err := a.client.ViewGuild(guildId)
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	a.guilds[guildId] = cachedGuild{guild: guild, expireAt: time.Now().Add(guildCacheDuration)}
+	a.mu.Unlock()
+	return guild, nil
+}
+
+// ToBotMessage 转换消息事件，频道消息会根据服务器主和角色权限设置发送者的角色。
+// 获取服务器详情失败时按普通成员处理
+func (a *Adapter) ToBotMessage(d EventData) (bot.Message, error) {
+	msg, err := d.ToBotMessage()
+	if err != nil || msg.MessageType != bot.MessageTypeGroup {
+		return msg, err
+	}
+	msg.Role = bot.RoleMember
+	if d.Extra.GuildId == "" {
+		return msg, nil
+	}
+	guild, err := a.viewGuild(d.Extra.GuildId)
+	if err != nil {
+		logging.L().Warn("view kook guild failed",
+			logging.Error(err),
+			logging.Any("guild_id", d.Extra.GuildId))
+		return msg, nil
+	}
+	msg.Role = guild.RoleOf(d.AuthorId, d.Extra.Author.Roles)
+	return msg, nil
+}
+
+func (a *Adapter) Platform() string {
+	return bot.PlatformKook
+}
+
+func (a *Adapter) Mention(userId int64) string {
+	return fmt.Sprintf("(met)%s(met) ", fromBotId(userId))
+}
+
+func (a *Adapter) Send(reply bot.Reply) error {
+	form := CreateMessageForm{
+		Type:    MessageTypeKMarkdown,
+		Content: reply.MessagePrefix + reply.Message,
+	}
+	if reply.IsPrivate() {
+		form.TargetId = fromBotId(reply.UserId)
+		return a.client.CreateDirectMessage(form)
+	}
+	form.TargetId = fromBotId(reply.GroupId)
+	return a.client.CreateMessage(form)
+}
+
+// ToBotMessage 将消息事件转换为平台无关的消息，kook 的频道对应群聊，私信对应私聊
+func (d EventData) ToBotMessage() (bot.Message, error) {
+	msg := bot.Message{
+		Platform: bot.PlatformKook,
+		Content:  d.RawContent(),
+	}
+	userId, err := toBotId(d.AuthorId)
+	if err != nil {
+		return msg, err
+	}
+	msg.UserId = userId
+	switch d.ChannelType {
+	case ChannelTypeGroup:
+		groupId, err := toBotId(d.TargetId)
+		if err != nil {
+			return msg, err
+		}
+		msg.MessageType = bot.MessageTypeGroup
+		msg.GroupId = groupId
+	case ChannelTypePerson:
+		msg.MessageType = bot.MessageTypePrivate
+	default:
+		return msg, fmt.Errorf("channel_type %s not supported", d.ChannelType)
+	}
+	return msg, nil
+}
+
+// IsUserMessage 是否是用户发送的文本消息，系统消息和机器人消息不需要处理
+func (d EventData) IsUserMessage() bool {
+	if d.Type != MessageTypeText && d.Type != MessageTypeKMarkdown {
+		return false
+	}
+	return !d.Extra.Author.Bot
+}
@@ -0,0 +1,83 @@
+package kook
+
+import (
+	"fmt"
+	"github.com/go-resty/resty/v2"
+	"time"
+)
+
+const DefaultBaseUrl = "https://www.kookapp.cn/api/v3"
+
+// Client kook http api 客户端
+type Client struct {
+	baseUrl string
+	token   string
+	client  *resty.Client
+}
+
+func NewClient(baseUrl string, token string) *Client {
+	if baseUrl == "" {
+		baseUrl = DefaultBaseUrl
+	}
+	return &Client{
+		baseUrl: baseUrl,
+		token:   token,
+		client:  resty.New().SetTimeout(time.Second * 20),
+	}
+}
+
+func (c *Client) post(path string, body any) error {
+	var commonResp CommonResponse
+	resp, err := c.client.R().
+		SetHeader("Authorization", "Bot "+c.token).
+		SetHeader("Content-Type", "application/json").
+		SetBody(body).
+		SetResult(&commonResp).
+		Post(c.baseUrl + path)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("post %s error, status code %d, resp %s", path, resp.StatusCode(), resp.String())
+	}
+	if commonResp.Code != 0 {
+		return fmt.Errorf("call %s failed, code %d, message %s", path, commonResp.Code, commonResp.Message)
+	}
+	return nil
+}
+
+// ViewGuild 获取服务器详情
+// https://developer.kookapp.cn/doc/http/guild#%E8%8E%B7%E5%8F%96%E6%9C%8D%E5%8A%A1%E5%99%A8%E8%AF%A6%E6%83%85
+func (c *Client) ViewGuild(guildId string) (*Guild, error) {
+	var guildResp struct {
+		CommonResponse
+		Data Guild `json:"data"`
+	}
+	resp, err := c.client.R().
+		SetHeader("Authorization", "Bot "+c.token).
+		SetQueryParam("guild_id", guildId).
+		SetResult(&guildResp).
+		Get(c.baseUrl + "/guild/view")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("get /guild/view error, status code %d, resp %s", resp.StatusCode(), resp.String())
+	}
+	if guildResp.Code != 0 {
+		return nil, fmt.Errorf("call /guild/view failed, code %d, message %s", guildResp.Code, guildResp.Message)
+	}
+	return &guildResp.Data, nil
+}
+
+// CreateMessage 发送频道消息
+// https://developer.kookapp.cn/doc/http/message#%E5%8F%91%E9%80%81%E9%A2%91%E9%81%93%E8%81%8A%E5%A4%A9%E6%B6%88%E6%81%AF
+func (c *Client) CreateMessage(form CreateMessageForm) error {
+	return c.post("/message/create", form)
+}
+
+// CreateDirectMessage 发送私信
+// https://developer.kookapp.cn/doc/http/direct-message#%E5%8F%91%E9%80%81%E7%A7%81%E4%BF%A1%E8%81%8A%E5%A4%A9%E6%B6%88%E6%81%AF
+func (c *Client) CreateDirectMessage(form CreateMessageForm) error {
+	return c.post("/direct-message/create", form)
+}
@@ -0,0 +1,252 @@
+package kook
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/axiangcoding/antonstar-bot/pkg/bot"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+const (
+	testEncryptKey  = "encryptKey"
+	testVerifyToken = "verifyToken"
+	testBotToken    = "botToken"
+)
+
+const challengeBody = `{"s":0,"d":{"type":255,"channel_type":"WEBHOOK_CHALLENGE","challenge":"bkldfjosdf","verify_token":"verifyToken"}}`
+
+const groupMessageBody = `{"s":0,"d":{"type":9,"channel_type":"GROUP","target_id":"6480729836729623","author_id":"2418200000",` +
+	`"content":".cqbot 查询 GodFather\\_33","msg_id":"67637d4c","verify_token":"verifyToken",` +
+	`"extra":{"guild_id":"1234","author":{"id":"2418200000","username":"tester","bot":false},` +
+	`"kmarkdown":{"raw_content":".cqbot 查询 GodFather_33"}}},"sn":1}`
+
+const personMessageBody = `{"s":0,"d":{"type":1,"channel_type":"PERSON","target_id":"1000","author_id":"2418200000",` +
+	`"content":".cqbot 气运","verify_token":"verifyToken","extra":{"author":{"id":"2418200000","bot":false}}},"sn":2}`
+
+// encrypt 按照kook的加密方式加密，用于模拟开启了消息加密的webhook推送
+func encrypt(t *testing.T, plain []byte, key string) []byte {
+	block, err := aes.NewCipher(paddingKey(key))
+	assert.NoError(t, err)
+	padding := aes.BlockSize - len(plain)%aes.BlockSize
+	plain = append(plain, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	iv := []byte("0123456789abcdef")
+	ciphertext := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plain)
+	inner := base64.StdEncoding.EncodeToString(ciphertext)
+	outer := base64.StdEncoding.EncodeToString(append(iv, []byte(inner)...))
+	body, err := json.Marshal(map[string]string{"encrypt": outer})
+	assert.NoError(t, err)
+	return body
+}
+
+func compress(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDecodeWebhookBody(t *testing.T) {
+	tests := []struct {
+		body      []byte
+		challenge bool
+		content   string
+	}{
+		{body: []byte(challengeBody), challenge: true},
+		{body: encrypt(t, []byte(challengeBody), testEncryptKey), challenge: true},
+		{body: compress(t, encrypt(t, []byte(groupMessageBody), testEncryptKey)), content: ".cqbot 查询 GodFather_33"},
+		{body: compress(t, []byte(personMessageBody)), content: ".cqbot 气运"},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			payload, err := DecodeWebhookBody(tt.body, testEncryptKey)
+			assert.NoError(t, err)
+			assert.Equal(t, testVerifyToken, payload.D.VerifyToken)
+			assert.Equal(t, tt.challenge, payload.D.IsChallenge())
+			if tt.challenge {
+				assert.Equal(t, "bkldfjosdf", payload.D.Challenge)
+			} else {
+				assert.Equal(t, tt.content, payload.D.RawContent())
+			}
+		})
+	}
+}
+
+func TestDecodeWebhookBodyWrongKey(t *testing.T) {
+	_, err := DecodeWebhookBody(encrypt(t, []byte(challengeBody), testEncryptKey), "anotherKey")
+	assert.Error(t, err)
+}
+
+func TestEventDataToBotMessage(t *testing.T) {
+	group, err := DecodeWebhookBody([]byte(groupMessageBody), "")
+	assert.NoError(t, err)
+	msg, err := group.D.ToBotMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, bot.Message{
+		Platform:    bot.PlatformKook,
+		MessageType: bot.MessageTypeGroup,
+		GroupId:     -6480729836729623,
+		UserId:      -2418200000,
+		Content:     ".cqbot 查询 GodFather_33",
+	}, msg)
+
+	person, err := DecodeWebhookBody([]byte(personMessageBody), "")
+	assert.NoError(t, err)
+	msg, err = person.D.ToBotMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, bot.MessageTypePrivate, msg.MessageType)
+	assert.Equal(t, int64(-2418200000), msg.UserId)
+	assert.True(t, person.D.IsUserMessage())
+}
+
+func TestEventDataToBotMessageInvalidId(t *testing.T) {
+	tests := []string{"", "abc", "0", "-2418200000"}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, err := EventData{AuthorId: tt, ChannelType: ChannelTypePerson}.ToBotMessage()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		arg string
+		id  int64
+		ok  bool
+	}{
+		{arg: "(met)2418200000(met)", id: -2418200000, ok: true},
+		{arg: "2418200000", id: -2418200000, ok: true},
+		{arg: "-2418200000", id: -2418200000, ok: true},
+		{arg: "(met)(met)", id: 0, ok: false},
+		{arg: "(met)abc(met)", id: 0, ok: false},
+		{arg: "(met)-2418200000(met)", id: 0, ok: false},
+		{arg: "0", id: 0, ok: false},
+		{arg: "abc", id: 0, ok: false},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			id, ok := ParseTarget(tt.arg)
+			assert.Equal(t, tt.id, id)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+// fakeKookServer 模拟kook的消息接口，记录收到的请求
+func fakeKookServer(t *testing.T, received map[string]CreateMessageForm) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot "+testBotToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":401,"message":"你的用户凭证不正确"}`))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var form CreateMessageForm
+		assert.NoError(t, json.Unmarshal(body, &form))
+		received[r.URL.Path] = form
+		_, _ = w.Write([]byte(`{"code":0,"message":"操作成功","data":{"msg_id":"abc"}}`))
+	}))
+}
+
+func TestAdapterSend(t *testing.T) {
+	received := make(map[string]CreateMessageForm)
+	server := fakeKookServer(t, received)
+	defer server.Close()
+	adapter := NewAdapter(NewClient(server.URL, testBotToken))
+
+	err := adapter.Send(bot.Reply{
+		Platform:      bot.PlatformKook,
+		MessageType:   bot.MessageTypeGroup,
+		GroupId:       -6480729836729623,
+		MessagePrefix: adapter.Mention(-2418200000),
+		Message:       "你好",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, CreateMessageForm{
+		Type:     MessageTypeKMarkdown,
+		TargetId: "6480729836729623",
+		Content:  "(met)2418200000(met) 你好",
+	}, received["/message/create"])
+
+	err = adapter.Send(bot.Reply{
+		Platform:    bot.PlatformKook,
+		MessageType: bot.MessageTypePrivate,
+		UserId:      -2418200000,
+		Message:     "私聊你好",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2418200000", received["/direct-message/create"].TargetId)
+	assert.Equal(t, "私聊你好", received["/direct-message/create"].Content)
+}
+
+func TestAdapterSendUnauthorized(t *testing.T) {
+	server := fakeKookServer(t, make(map[string]CreateMessageForm))
+	defer server.Close()
+	adapter := NewAdapter(NewClient(server.URL, "wrongToken"))
+	err := adapter.Send(bot.Reply{MessageType: bot.MessageTypeGroup, GroupId: 1, Message: "你好"})
+	assert.Error(t, err)
+}
+
+const testGuildBody = `{"code":0,"message":"操作成功","data":{"id":"1234","name":"安东星","master_id":"1000",` +
+	`"roles":[{"role_id":0,"name":"@全体成员","permissions":142924296},` +
+	`{"role_id":11,"name":"管理员","permissions":1},{"role_id":12,"name":"活跃成员","permissions":4096}]}}`
+
+func TestAdapterToBotMessageRole(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "/guild/view", r.URL.Path)
+		assert.Equal(t, "Bot "+testBotToken, r.Header.Get("Authorization"))
+		if r.URL.Query().Get("guild_id") != "1234" {
+			_, _ = w.Write([]byte(`{"code":403,"message":"你没有权限"}`))
+			return
+		}
+		_, _ = w.Write([]byte(testGuildBody))
+	}))
+	defer server.Close()
+	adapter := NewAdapter(NewClient(server.URL, testBotToken))
+
+	tests := []struct {
+		guildId  string
+		authorId string
+		roles    []int
+		role     string
+	}{
+		{guildId: "1234", authorId: "1000", role: bot.RoleOwner},
+		{guildId: "1234", authorId: "2418200000", roles: []int{12, 11}, role: bot.RoleAdmin},
+		{guildId: "1234", authorId: "2418200000", roles: []int{12}, role: bot.RoleMember},
+		{guildId: "1234", authorId: "2418200000", role: bot.RoleMember},
+		// 获取服务器详情失败时按普通成员处理
+		{guildId: "5678", authorId: "1000", role: bot.RoleMember},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			event := EventData{ChannelType: ChannelTypeGroup, TargetId: "6480729836729623", AuthorId: tt.authorId}
+			event.Extra.GuildId = tt.guildId
+			event.Extra.Author.Roles = tt.roles
+			msg, err := adapter.ToBotMessage(event)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.role, msg.Role)
+		})
+	}
+	// 同一个服务器的详情会被缓存
+	assert.Equal(t, 2, requests)
+
+	msg, err := adapter.ToBotMessage(EventData{ChannelType: ChannelTypePerson, AuthorId: "1000"})
+	assert.NoError(t, err)
+	assert.Empty(t, msg.Role)
+}
@@ -0,0 +1,113 @@
+package kook
+
+import "github.com/axiangcoding/antonstar-bot/pkg/bot"
+
+const (
+	ChannelTypeGroup     = "GROUP"
+	ChannelTypePerson    = "PERSON"
+	ChannelTypeBroadcast = "BROADCAST"
+	ChannelTypeChallenge = "WEBHOOK_CHALLENGE"
+)
+
+const (
+	MessageTypeText      = 1
+	MessageTypeKMarkdown = 9
+	MessageTypeSystem    = 255
+)
+
+// WebhookPayload webhook推送的信令
+// https://developer.kookapp.cn/doc/webhook
+type WebhookPayload struct {
+	S  int       `json:"s"`
+	D  EventData `json:"d"`
+	Sn int       `json:"sn"`
+}
+
+// EventData 事件内容，webhook验证请求也使用该结构
+type EventData struct {
+	Type         int    `json:"type"`
+	ChannelType  string `json:"channel_type"`
+	Challenge    string `json:"challenge"`
+	VerifyToken  string `json:"verify_token"`
+	TargetId     string `json:"target_id"`
+	AuthorId     string `json:"author_id"`
+	Content      string `json:"content"`
+	MsgId        string `json:"msg_id"`
+	MsgTimestamp int64  `json:"msg_timestamp"`
+	Nonce        string `json:"nonce"`
+	Extra        struct {
+		GuildId     string `json:"guild_id"`
+		ChannelName string `json:"channel_name"`
+		Author      struct {
+			Id       string `json:"id"`
+			Username string `json:"username"`
+			Nickname string `json:"nickname"`
+			Bot      bool   `json:"bot"`
+			// 用户在服务器中的角色id
+			Roles []int `json:"roles"`
+		} `json:"author"`
+		KMarkdown struct {
+			RawContent string `json:"raw_content"`
+		} `json:"kmarkdown"`
+	} `json:"extra"`
+}
+
+// IsChallenge 是否是webhook地址验证请求
+func (d EventData) IsChallenge() bool {
+	return d.ChannelType == ChannelTypeChallenge
+}
+
+// RawContent 获取去除kmarkdown转义后的消息内容
+func (d EventData) RawContent() string {
+	if d.Type == MessageTypeKMarkdown && d.Extra.KMarkdown.RawContent != "" {
+		return d.Extra.KMarkdown.RawContent
+	}
+	return d.Content
+}
+
+type CommonResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// PermissionAdmin 角色权限中的管理员权限位
+// https://developer.kookapp.cn/doc/objects#%E6%9D%83%E9%99%90%E8%AF%B4%E6%98%8E
+const PermissionAdmin = 1
+
+// Guild 服务器详情，只保留判断成员角色需要的字段
+type Guild struct {
+	Id       string      `json:"id"`
+	MasterId string      `json:"master_id"`
+	Roles    []GuildRole `json:"roles"`
+}
+
+type GuildRole struct {
+	RoleId      int    `json:"role_id"`
+	Name        string `json:"name"`
+	Permissions int    `json:"permissions"`
+}
+
+// RoleOf 用户在服务器内对应的平台无关角色，服务器主为群主，拥有管理员权限的角色为群管理员
+func (g Guild) RoleOf(userId string, roles []int) string {
+	if userId == g.MasterId {
+		return bot.RoleOwner
+	}
+	for _, role := range g.Roles {
+		if role.Permissions&PermissionAdmin == 0 {
+			continue
+		}
+		for _, id := range roles {
+			if id == role.RoleId {
+				return bot.RoleAdmin
+			}
+		}
+	}
+	return bot.RoleMember
+}
+
+type CreateMessageForm struct {
+	Type     int    `json:"type"`
+	TargetId string `json:"target_id"`
+	Content  string `json:"content"`
+	Quote    string `json:"quote,omitempty"`
+}
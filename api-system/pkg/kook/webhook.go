@@ -0,0 +1,88 @@
+package kook
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+var ErrDecryptFailed = errors.New("kook webhook payload decrypt failed")
+
+type encryptedPayload struct {
+	Encrypt string `json:"encrypt"`
+}
+
+// DecodeWebhookBody 解析webhook请求体。请求体可能经过zlib压缩，开启消息加密时还需要使用encryptKey解密
+func DecodeWebhookBody(body []byte, encryptKey string) (*WebhookPayload, error) {
+	// zlib 压缩数据以0x78开头，而json以'{'开头
+	if len(body) > 0 && body[0] == 0x78 {
+		r, err := zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		if body, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	var enc encryptedPayload
+	if err := json.Unmarshal(body, &enc); err != nil {
+		return nil, err
+	}
+	if enc.Encrypt != "" {
+		plain, err := Decrypt(enc.Encrypt, encryptKey)
+		if err != nil {
+			return nil, err
+		}
+		body = plain
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// Decrypt 解密encrypt字段。base64解码后前16位为iv，剩余部分为base64编码的密文，
+// 使用 AES-256-CBC 解密，密钥为 encryptKey 右补 \0 至32位
+func Decrypt(encrypted string, encryptKey string) ([]byte, error) {
+	if encryptKey == "" || len(encryptKey) > 32 {
+		return nil, ErrDecryptFailed
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(decoded) <= aes.BlockSize {
+		return nil, ErrDecryptFailed
+	}
+	iv := decoded[:aes.BlockSize]
+	ciphertext, err := base64.StdEncoding.DecodeString(string(decoded[aes.BlockSize:]))
+	if err != nil || len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, ErrDecryptFailed
+	}
+	block, err := aes.NewCipher(paddingKey(encryptKey))
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, ciphertext)
+	return pkcs7Unpadding(plain)
+}
+
+func paddingKey(key string) []byte {
+	k := make([]byte, 32)
+	copy(k, key)
+	return k
+}
+
+func pkcs7Unpadding(data []byte) ([]byte, error) {
+	length := len(data)
+	padding := int(data[length-1])
+	if padding == 0 || padding > aes.BlockSize || padding > length {
+		return nil, ErrDecryptFailed
+	}
+	return data[:length-padding], nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -59,15 +60,52 @@ type appConf struct {
 			Source string `mapstructure:"source"`
 		}
 	}
+	Mission struct {
+		// 执行任务的worker数量
+		Workers int `mapstructure:"workers"`
+		// 任务失败后最多执行的次数
+		MaxAttempts int `mapstructure:"max_attempts"`
+	}
+	Crawler struct {
+		// 轮流使用的代理地址
+		Proxies []string `mapstructure:"proxies"`
+		// cookie持久化的文件
+		CookieFile string `mapstructure:"cookie_file"`
+		// 对同一个站点两次请求之间的最小间隔
+		MinInterval time.Duration `mapstructure:"min_interval"`
+		// 被拦截后第一次退避的时间
+		BaseBackoff time.Duration `mapstructure:"base_backoff"`
+		// 退避时间的上限
+		MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	}
+	// PlayerRefresh 订阅和关注的玩家共用的定时刷新
+	PlayerRefresh struct {
+		// 每个玩家两次检查之间的间隔
+		Interval time.Duration `mapstructure:"interval"`
+		// 每次定时任务最多爬取的玩家数
+		BatchSize int `mapstructure:"batch_size"`
+	} `mapstructure:"player_refresh"`
 	Service struct {
-		CqHttp struct {
-			Url    string `mapstructure:"url"`
-			SelfQQ int64  `mapstructure:"self_qq"`
-			Secret string `mapstructure:"secret"`
+		// CqHttp 可以配置多个qq账号，第一个作为默认账号
+		CqHttp []CqHttpConf
+		Kook   struct {
+			Enable      bool   `mapstructure:"enable"`
+			BaseUrl     string `mapstructure:"base_url"`
+			Token       string `mapstructure:"token"`
+			VerifyToken string `mapstructure:"verify_token"`
+			EncryptKey  string `mapstructure:"encrypt_key"`
 		}
 	}
 }
 
+type CqHttpConf struct {
+	Url    string `mapstructure:"url"`
+	SelfQQ int64  `mapstructure:"self_qq"`
+	Secret string `mapstructure:"secret"`
+	// 反向websocket连接的access token
+	AccessToken string `mapstructure:"access_token"`
+}
+
 var _conf *GlobalConf
 
 func InitConf() {
@@ -90,3 +128,20 @@ func InitConf() {
 func C() *GlobalConf {
 	return _conf
 }
+
+// FindCqHttpConf 根据qq号查找cqhttp的配置，selfQQ为0时返回默认账号
+func FindCqHttpConf(selfQQ int64) (CqHttpConf, bool) {
+	accounts := C().App.Service.CqHttp
+	if len(accounts) == 0 {
+		return CqHttpConf{}, false
+	}
+	if selfQQ == 0 {
+		return accounts[0], true
+	}
+	for _, account := range accounts {
+		if account.SelfQQ == selfQQ {
+			return account, true
+		}
+	}
+	return CqHttpConf{}, false
+}
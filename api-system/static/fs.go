@@ -5,7 +5,7 @@ import (
 	"github.com/axiangcoding/antonstar-bot/pkg/logging"
 )
 
-//go:embed message
+//go:embed message card
 var fs embed.FS
 
 func MustReadMessageFileAsBytes(filename string) []byte {
@@ -18,3 +18,14 @@ func MustReadMessageFileAsBytes(filename string) []byte {
 	}
 	return bytes
 }
+
+func MustReadCardFileAsBytes(filename string) []byte {
+	bytes, err := fs.ReadFile("card/" + filename)
+	if err != nil {
+		logging.L().Warn("read card file error.",
+			logging.Any("filename", filename),
+			logging.Error(err))
+		return nil
+	}
+	return bytes
+}
@@ -0,0 +1,15 @@
+package static
+
+import (
+	"github.com/axiangcoding/antonstar-bot/pkg/cardfight"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCardCatalogue(t *testing.T) {
+	c, err := cardfight.ParseCatalogue(MustReadCardFileAsBytes("catalogue.json"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, c.Cards)
+	_, ok := c.Find("ZTZ99A")
+	assert.True(t, ok)
+}